@@ -11,3 +11,49 @@ type Adapter[T comparable] interface {
 	// indicating whether the given T is the finish or not
 	IsFinish(T) bool
 }
+
+// adapters can optionally implement WeightedAdapter when moving between
+// neighbours does not always cost the same. Without it, every step costs 1.
+type WeightedAdapter[T comparable] interface {
+	Adapter[T]
+
+	// to return the cost of moving from the first T to its neighbour
+	EdgeCost(T, T) int
+}
+
+// returns the cost of moving from -> to, as reported by the adapter.
+func edgeCost[T comparable](adapter Adapter[T], from, to T) int {
+	if weighted, ok := adapter.(WeightedAdapter[T]); ok {
+		return weighted.EdgeCost(from, to)
+	}
+
+	if actionAdapter, ok := labelsActions(adapter); ok {
+		if t, found := cheapestTransition(actionAdapter, from, to); found {
+			return t.Cost
+		}
+	}
+
+	return 1
+}
+
+// adapters wrapping another adapter, such as Constraints, can implement
+// Unwrapper to pass on optional interfaces like Indexer. They then only count
+// as implementing those when the adapter they wrap implements them too.
+type Unwrapper[T comparable] interface {
+	// to return the adapter that is wrapped
+	Unwrap() Adapter[T]
+}
+
+// returns the adapter as the optional interface I, such as Indexer, if it
+// implements it, and so does every adapter it unwraps to.
+func optional[I any, T comparable](adapter Adapter[T]) (I, bool) {
+	if u, ok := adapter.(Unwrapper[T]); ok {
+		if _, ok := optional[I](u.Unwrap()); !ok {
+			var zero I
+			return zero, false
+		}
+	}
+
+	i, ok := adapter.(I)
+	return i, ok
+}
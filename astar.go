@@ -1,32 +1,40 @@
 package pathfind
 
 import (
-	"github.com/tmw/pathfind/pkg/prioqueue"
+	"github.com/tmw/pathfind/pkg/frontier"
+	"github.com/tmw/pathfind/pkg/keymap"
 )
 
 type astar[T comparable] struct {
-	candidates *prioqueue.Prioqueue[candidate[T]]
+	candidates frontier.Frontier[T, int, int]
+	nodes      nodeSlab[T]
 	start      T
+
+	// node index of every node reached so far, both open and closed.
+	reached *keymap.Map[T, int]
 }
 
 func newAStar[T comparable](start T) *astar[T] {
 	return &astar[T]{
-		start:      start,
-		candidates: prioqueue.New[candidate[T]](),
+		start: start,
 	}
 }
 
 func (w *astar[T]) Walk(ctx SolveContext[T]) []T {
+	w.candidates = newFrontier[T](astarFrontier(ctx.Frontier, ctx.ReopenClosed), FrontierBinaryHeap)
+	w.reached = keymap.New[T, int](indexOf(ctx.Adapter()))
+
+	nearest := newClosest()
+
 	// add initial starting position
-	sc := candidate[T]{
-		coord:  w.start,
-		parent: nil,
-	}
-	w.candidates.Push(sc, ctx.Adapter().CostToFinish(w.start))
+	idx := w.nodes.add(w.start, noParent, 0)
+	w.candidates.Push(w.start, idx, ctx.Adapter().CostToFinish(w.start))
+	w.reached.Set(w.start, idx)
 
 	// main loop
 	for w.candidates.Len() > 0 {
-		currentNode := w.candidates.Pop()
+		_, idx := w.candidates.Pop()
+		currentNode := w.nodes.at(idx)
 
 		if ctx.MaxCost > 0 && currentNode.cost >= ctx.MaxCost {
 			ctx.Publish(EventMaxCostReached{})
@@ -34,45 +42,65 @@ func (w *astar[T]) Walk(ctx SolveContext[T]) []T {
 		}
 
 		if ctx.Adapter().IsFinish(currentNode.coord) {
-			path := backtrace[T](currentNode)
-			ctx.Publish(EventFinishReached[T]{Path: path})
+			path := backtrace[T](w.nodes.at, idx)
+			ctx.Publish(EventFinishReached[T]{Path: path, Actions: actionsAlong(ctx.Adapter(), path)})
 			return path
 		}
 
+		ctx.Visit(currentNode.coord)
+		ctx.Publish(EventCandidateVisited[T]{CandidateID: currentNode.coord})
+		nearest.offer(idx, ctx.Adapter().CostToFinish(currentNode.coord))
+
 		neighbours := ctx.Adapter().Neighbours(currentNode.coord)
 		for _, n := range neighbours {
-			if ctx.IsVisited(n) {
-				continue
+			if ctx.CheckConsistency {
+				checkConsistency(ctx, currentNode.coord, n)
 			}
 
-			newCandidate := candidate[T]{
-				coord:  n,
-				parent: &currentNode,
-				cost:   currentNode.cost + 1,
-			}
+			cost := currentNode.cost + edgeCost(ctx.Adapter(), currentNode.coord, n)
+			w.relax(ctx, idx, n, cost)
+		}
+	}
 
-			predicate := func(i candidate[T]) bool {
-				return i.coord == newCandidate.coord
-			}
+	ctx.Publish(EventUnsolvable{})
+	return partialPath(ctx, w.nodes.at, nearest)
+}
 
-			existingCandidateIdx := w.candidates.IndexFunc(predicate)
-			if existingCandidateIdx > 0 {
-				existingCandidate := w.candidates.PeekItem(existingCandidateIdx)
-				newCost := ctx.Adapter().CostToFinish(n) + existingCandidate.cost
-				if newCost < w.candidates.PriorityOfItem(existingCandidateIdx) {
-					w.candidates.UpdateAtIndex(existingCandidateIdx, newCandidate, newCost)
-				}
-			} else {
-				neighbourCost := ctx.Adapter().CostToFinish(n) + newCandidate.cost
-				w.candidates.Push(newCandidate, neighbourCost)
-				ctx.Publish(EventCandidateAdded[T]{CandidateID: newCandidate.coord})
-			}
-		}
+// offers n, reached from parent at the given cost, to the frontier, either
+// as a new candidate, as a cheaper route to a candidate already on the
+// frontier, or, with ReopenClosed, as a cheaper route to a node that was
+// already expanded.
+func (w *astar[T]) relax(ctx SolveContext[T], parent int, n T, cost int) {
+	idx, reached := w.reached.Get(n)
+	if reached && cost >= w.nodes.at(idx).cost {
+		return
+	}
 
-		ctx.Visit(currentNode.coord)
-		ctx.Publish(EventCandidateVisited[T]{CandidateID: currentNode.coord})
+	closed := ctx.IsVisited(n)
+	if closed && !ctx.ReopenClosed {
+		return
 	}
 
-	ctx.Publish(EventUnsolvable{})
-	return []T{}
+	if reached {
+		w.nodes.reparent(idx, parent, cost)
+	} else {
+		idx = w.nodes.add(n, parent, cost)
+		w.reached.Set(n, idx)
+	}
+
+	queued := w.candidates.Contains(n)
+	w.candidates.Push(n, idx, cost+ctx.Adapter().CostToFinish(n))
+
+	if queued || closed {
+		ctx.Publish(EventCandidateUpdated[T]{CandidateID: n, Parent: w.nodes.at(parent).coord})
+	} else {
+		ctx.Publish(EventCandidateAdded[T]{CandidateID: n})
+	}
+}
+
+func checkConsistency[T comparable](ctx SolveContext[T], from, to T) {
+	adapter := ctx.Adapter()
+	if adapter.CostToFinish(from) > edgeCost(adapter, from, to)+adapter.CostToFinish(to) {
+		ctx.Publish(EventHeuristicInconsistent[T]{From: from, To: to})
+	}
 }
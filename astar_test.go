@@ -0,0 +1,191 @@
+package pathfind
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/tmw/pathfind/pkg/arena"
+)
+
+func TestAStarIsOptimal(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		adapter := randomMaze(24, seed)
+		start := [2]int{0, 0}
+
+		expected, reachable := dijkstra[[2]int](adapter, start).dist[[2]int{23, 23}]
+
+		s := NewSolver[[2]int](AlgorithmAStar, start, adapter)
+		path := s.Walk()
+
+		if !reachable {
+			if len(path) != 0 {
+				t.Errorf("seed %d: expected no path but got %v", seed, path)
+			}
+			continue
+		}
+
+		if actual := pathCost[[2]int](adapter, path); actual != expected {
+			t.Errorf("seed %d: expected path cost %d but got %d", seed, expected, actual)
+		}
+	}
+}
+
+// an admissible heuristic that is inconsistent along b → a, so a is expanded
+// through the expensive edge before the cheaper route through b is known.
+func inconsistentGraph() *FuncAdapter[string] {
+	edges := map[string]map[string]int{
+		"s": {"a": 4, "b": 1},
+		"b": {"a": 1},
+		"a": {"g": 4},
+	}
+	estimates := map[string]int{"s": 6, "a": 0, "b": 5}
+
+	return &FuncAdapter[string]{
+		NeighboursFn: func(n string) []string {
+			neighbours := []string{}
+			for _, to := range []string{"a", "b", "g"} {
+				if _, found := edges[n][to]; found {
+					neighbours = append(neighbours, to)
+				}
+			}
+			return neighbours
+		},
+		CostToFinishFn: func(n string) int { return estimates[n] },
+		IsFinishFn:     func(n string) bool { return n == "g" },
+		EdgeCostFn:     func(from, to string) int { return edges[from][to] },
+	}
+}
+
+func TestAStarReopenClosed(t *testing.T) {
+	testData := []struct {
+		name         string
+		reopenClosed bool
+		expected     []string
+	}{
+		{name: "closed nodes stay closed", reopenClosed: false, expected: []string{"g", "a", "s"}},
+		{name: "closed nodes are reopened", reopenClosed: true, expected: []string{"g", "a", "b", "s"}},
+	}
+
+	for _, td := range testData {
+		td := td
+		t.Run(td.name, func(t *testing.T) {
+			s := NewSolver[string](AlgorithmAStar, "s", inconsistentGraph())
+			s.ReopenClosed = td.reopenClosed
+			path := s.Walk()
+
+			if len(path) != len(td.expected) {
+				t.Fatalf("expected path %v but got %v", td.expected, path)
+			}
+
+			for idx := range path {
+				if path[idx] != td.expected[idx] {
+					t.Fatalf("expected path %v but got %v", td.expected, path)
+				}
+			}
+		})
+	}
+}
+
+func TestAStarPublishesCandidateUpdated(t *testing.T) {
+	s := NewSolver[string](AlgorithmAStar, "s", inconsistentGraph())
+	s.ReopenClosed = true
+	s.Walk()
+
+	expected := []EventCandidateUpdated[string]{
+		{CandidateID: "a", Parent: "b"},
+		{CandidateID: "g", Parent: "a"},
+	}
+
+	actual := []EventCandidateUpdated[string]{}
+	for _, e := range s.EventLog() {
+		if updated, ok := e.(EventCandidateUpdated[string]); ok {
+			actual = append(actual, updated)
+		}
+	}
+
+	if len(actual) != len(expected) {
+		t.Fatalf("expected events %v but got %v", expected, actual)
+	}
+
+	for idx := range actual {
+		if actual[idx] != expected[idx] {
+			t.Errorf("expected event %v but got %v", expected[idx], actual[idx])
+		}
+	}
+}
+
+// a square arena with start and finish in opposite corners, split by a wall
+// with a gap at the far end so the search floods most of the arena.
+func benchmarkArena(b testing.TB, size int) *arena.Arena {
+	var sb strings.Builder
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			switch {
+			case x == 0 && y == 0:
+				sb.WriteString(arena.SymbolStart)
+			case x == size-1 && y == size-1:
+				sb.WriteString(arena.SymbolFinish)
+			case y == size/2 && x < size-1:
+				sb.WriteString(arena.SymbolNonWalkable)
+			default:
+				sb.WriteString(arena.SymbolWalkable)
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	a, err := arena.Parse(sb.String())
+	if err != nil {
+		b.Fatal(err)
+	}
+	return a
+}
+
+// pins down where a walk allocates: once per event published, boxing it into
+// an Event, and once per call to Neighbours, for the slice the arena returns.
+// The slab, reached nodes and frontier only allocate as they grow, which
+// leaves them a small allowance rather than a share per node.
+func TestAStarAllocations(t *testing.T) {
+	a := benchmarkArena(t, 100)
+	walk := func() *Solver[arena.Coordinate] {
+		adapter := arena.NewAdapter(a, a.FinishCoordinate())
+		s := NewSolver[arena.Coordinate](AlgorithmAStar, a.StartCoordinate(), adapter)
+		if len(s.Walk()) == 0 {
+			t.Fatal("expected a path")
+		}
+		return &s
+	}
+
+	events := walk().EventLog()
+	expanded := 0
+	for _, e := range events {
+		if _, ok := e.(EventCandidateVisited[arena.Coordinate]); ok {
+			expanded++
+		}
+	}
+
+	bound := float64(len(events) + expanded + 200)
+	if allocs := testing.AllocsPerRun(5, func() { walk() }); allocs > bound {
+		t.Errorf("expected at most %.0f allocations for %d events and %d expansions, got %.0f",
+			bound, len(events), expanded, allocs)
+	}
+}
+
+// solves arenas of increasing size. See TestAStarAllocations for what the
+// allocations per walk come down to.
+func BenchmarkAStar(b *testing.B) {
+	for _, size := range []int{100, 1000} {
+		a := benchmarkArena(b, size)
+		b.Run(fmt.Sprintf("%dx%d", size, size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				adapter := arena.NewAdapter(a, a.FinishCoordinate())
+				s := NewSolver[arena.Coordinate](AlgorithmAStar, a.StartCoordinate(), adapter)
+				if len(s.Walk()) == 0 {
+					b.Fatal("expected a path")
+				}
+			}
+		})
+	}
+}
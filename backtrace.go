@@ -1,17 +1,14 @@
 package pathfind
 
-// given a candidate that is marked as the finish,
+// given the index of a node that is marked as the finish,
 // backtrace to the start and return the path from finish to start.
-func backtrace[T comparable](c candidate[T]) []T {
+func backtrace[T comparable](at func(int) node[T], idx int) []T {
 	path := []T{}
 
-	hop := c
-	for {
+	for idx != noParent {
+		hop := at(idx)
 		path = append(path, hop.coord)
-		if hop.parent == nil {
-			break
-		}
-		hop = *hop.parent
+		idx = hop.parent
 	}
 
 	return path
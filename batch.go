@@ -0,0 +1,121 @@
+package pathfind
+
+import (
+	"runtime"
+	"sync"
+)
+
+// a single start-to-finish search in a batch. Without HasFinish, the search
+// runs towards whatever the adapter considers the finish.
+type Query[T comparable] struct {
+	Start     T
+	Finish    T
+	HasFinish bool
+}
+
+type BatchResult[T comparable] struct {
+	// path from finish to start, empty when the finish wasn't reached.
+	Path []T
+	Cost int
+}
+
+// adapters can optionally implement Estimator to guide searches towards
+// finishes other than their own, as set per Query. Without it, such
+// searches run without a heuristic.
+type Estimator[T comparable] interface {
+	// to return the estimated cost of moving from the first T to the second
+	Estimate(T, T) int
+}
+
+// solves many queries against one adapter, spread over a pool of workers.
+// Every query gets a solver of its own, so the adapter is the only state
+// shared between workers and must be safe for concurrent reads.
+type Batch[T comparable] struct {
+	algorithm Algorithm
+	adapter   Adapter[T]
+
+	// number of queries solved at the same time, defaults to GOMAXPROCS
+	Workers int
+
+	// passed on to the solver of every query
+	MaxCost  int
+	Frontier FrontierKind
+}
+
+func NewBatch[T comparable](algorithm Algorithm, adapter Adapter[T]) *Batch[T] {
+	return &Batch[T]{
+		algorithm: algorithm,
+		adapter:   adapter,
+	}
+}
+
+// returns a result for every query, in the order of the queries.
+func (b *Batch[T]) Solve(queries []Query[T]) []BatchResult[T] {
+	workers := b.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	results := make([]BatchResult[T], len(queries))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < min(workers, len(queries)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				results[idx] = b.solve(queries[idx])
+			}
+		}()
+	}
+
+	for idx := range queries {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+func (b *Batch[T]) solve(q Query[T]) BatchResult[T] {
+	adapter := b.adapter
+	if q.HasFinish {
+		adapter = &finishAdapter[T]{Adapter: b.adapter, finish: q.Finish}
+	}
+
+	s := NewSolver[T](b.algorithm, q.Start, adapter)
+	s.Visited = visitedSetFor(b.adapter)
+	s.MaxCost = b.MaxCost
+	s.Frontier = b.Frontier
+
+	path := s.Walk()
+	if len(path) == 0 {
+		return BatchResult[T]{Path: path}
+	}
+
+	return BatchResult[T]{Path: path, Cost: pathCost(b.adapter, path)}
+}
+
+// runs an adapter towards another finish than its own.
+type finishAdapter[T comparable] struct {
+	Adapter[T]
+	finish T
+}
+
+func (a *finishAdapter[T]) CostToFinish(n T) int {
+	if estimator, ok := optional[Estimator[T]](a.Adapter); ok {
+		return estimator.Estimate(n, a.finish)
+	}
+
+	return 0
+}
+
+func (a *finishAdapter[T]) IsFinish(n T) bool {
+	return n == a.finish
+}
+
+func (a *finishAdapter[T]) EdgeCost(from, to T) int {
+	return edgeCost(a.Adapter, from, to)
+}
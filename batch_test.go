@@ -0,0 +1,76 @@
+package pathfind
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/tmw/pathfind/pkg/arena"
+)
+
+func TestBatchSolve(t *testing.T) {
+	adapter := randomMaze(20, 7)
+	r := rand.New(rand.NewSource(1))
+
+	queries := []Query[[2]int]{}
+	for i := 0; i < 100; i++ {
+		q := Query[[2]int]{Start: [2]int{r.Intn(20), r.Intn(20)}}
+		if i%4 != 0 {
+			q.Finish, q.HasFinish = [2]int{r.Intn(20), r.Intn(20)}, true
+		}
+		queries = append(queries, q)
+	}
+
+	for _, algorithm := range []Algorithm{AlgorithmAStar, AlgorithmBFS} {
+		b := NewBatch[[2]int](algorithm, adapter)
+		b.Workers = 4
+		results := b.Solve(queries)
+
+		if len(results) != len(queries) {
+			t.Fatalf("expected %d results, got %d", len(queries), len(results))
+		}
+
+		for idx, q := range queries {
+			finish := [2]int{19, 19}
+			if q.HasFinish {
+				finish = q.Finish
+			}
+
+			expected, reachable := dijkstra[[2]int](adapter, q.Start).dist[finish]
+			actual := results[idx]
+
+			if !reachable {
+				if len(actual.Path) != 0 {
+					t.Errorf("query %d: expected no path but got %v", idx, actual.Path)
+				}
+				continue
+			}
+
+			if len(actual.Path) == 0 || actual.Path[0] != finish || actual.Path[len(actual.Path)-1] != q.Start {
+				t.Errorf("query %d: expected a path from %v to %v, got %v", idx, q.Start, finish, actual.Path)
+				continue
+			}
+
+			// BFS finds the fewest steps, which is not necessarily cheapest.
+			if algorithm == AlgorithmAStar && actual.Cost != expected {
+				t.Errorf("query %d: expected cost %d but got %d", idx, expected, actual.Cost)
+			}
+		}
+	}
+}
+
+func TestBatchSolveUsesEstimator(t *testing.T) {
+	a, err := arena.Parse("S....\n.###.\n....F")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := NewBatch[arena.Coordinate](AlgorithmAStar, arena.NewAdapter(a, a.FinishCoordinate()))
+	results := b.Solve([]Query[arena.Coordinate]{
+		{Start: a.StartCoordinate(), Finish: arena.NewCoordinate(4, 0), HasFinish: true},
+		{Start: a.StartCoordinate()},
+	})
+
+	if results[0].Cost != 4 || results[1].Cost != 6 {
+		t.Errorf("expected costs 4 and 6, got %d and %d", results[0].Cost, results[1].Cost)
+	}
+}
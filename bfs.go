@@ -1,37 +1,29 @@
 package pathfind
 
 import (
-	"slices"
-
-	"github.com/tmw/pathfind/pkg/queue"
-	"github.com/tmw/pathfind/pkg/slice"
+	"github.com/tmw/pathfind/pkg/frontier"
 )
 
 type bfs[T comparable] struct {
-	candidates queue.Queue[candidate[T]]
+	candidates frontier.Frontier[T, int, int]
+	nodes      nodeSlab[T]
+	start      T
 }
 
 func newBFS[T comparable](start T) *bfs[T] {
-	sc := candidate[T]{
-		coord:  start,
-		parent: nil,
-	}
-
-	candidates := queue.New[candidate[T]](sc)
-
 	return &bfs[T]{
-		candidates: candidates,
+		start: start,
 	}
 }
 
 func (w *bfs[T]) Walk(ctx SolveContext[T]) []T {
-	for w.candidates.Len() > 0 {
-		c := w.candidates.Pop()
+	w.candidates = newFrontier[T](ctx.Frontier, FrontierFIFO)
+	w.candidates.Push(w.start, w.nodes.add(w.start, noParent, 0), 0)
+	nearest := newClosest()
 
-		// in case we enqueued the same node multiple times.
-		if ctx.IsVisited(c.coord) {
-			continue
-		}
+	for w.candidates.Len() > 0 {
+		_, idx := w.candidates.Pop()
+		c := w.nodes.at(idx)
 
 		ctx.Publish(EventCandidateVisited[T]{CandidateID: c.coord})
 
@@ -41,31 +33,25 @@ func (w *bfs[T]) Walk(ctx SolveContext[T]) []T {
 		}
 
 		if ctx.Adapter().IsFinish(c.coord) {
-			path := backtrace[T](c)
-			ctx.Publish(EventFinishReached[T]{Path: path})
+			path := backtrace[T](w.nodes.at, idx)
+			ctx.Publish(EventFinishReached[T]{Path: path, Actions: actionsAlong(ctx.Adapter(), path)})
 			return path
 		}
 
 		ctx.Visit(c.coord)
+		nearest.offer(idx, ctx.Adapter().CostToFinish(c.coord))
 
-		neighbours := ctx.Adapter().Neighbours(c.coord)
-		unvisited := slices.DeleteFunc(neighbours, ctx.IsVisited)
-
-		for idx := range unvisited {
-			ctx.Publish(EventCandidateAdded[T]{CandidateID: unvisited[idx]})
-		}
-
-		candidates := slice.Map(unvisited, func(n T) candidate[T] {
-			return candidate[T]{
-				coord:  n,
-				cost:   c.cost + 1,
-				parent: &c,
+		for _, n := range ctx.Adapter().Neighbours(c.coord) {
+			// the first time a node is reached is via the fewest steps.
+			if ctx.IsVisited(n) || w.candidates.Contains(n) {
+				continue
 			}
-		})
 
-		w.candidates.Push(candidates...)
+			ctx.Publish(EventCandidateAdded[T]{CandidateID: n})
+			w.candidates.Push(n, w.nodes.add(n, idx, c.cost+1), c.cost+1)
+		}
 	}
 
 	ctx.Publish(EventUnsolvable{})
-	return []T{}
+	return partialPath(ctx, w.nodes.at, nearest)
 }
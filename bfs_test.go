@@ -0,0 +1,53 @@
+package pathfind
+
+import (
+	"testing"
+
+	"github.com/tmw/pathfind/pkg/arena"
+)
+
+func TestBFSFindsShortestPath(t *testing.T) {
+	for seed := int64(0); seed < 10; seed++ {
+		adapter := randomMaze(16, seed)
+		start := [2]int{0, 0}
+
+		unweighted := &FuncAdapter[[2]int]{
+			NeighboursFn:   adapter.NeighboursFn,
+			CostToFinishFn: adapter.CostToFinishFn,
+			IsFinishFn:     adapter.IsFinishFn,
+		}
+
+		expected, reachable := dijkstra[[2]int](unweighted, start).dist[[2]int{15, 15}]
+
+		s := NewSolver[[2]int](AlgorithmBFS, start, unweighted)
+		path := s.Walk()
+
+		if !reachable {
+			if len(path) != 0 {
+				t.Errorf("seed %d: expected no path but got %v", seed, path)
+			}
+			continue
+		}
+
+		if len(path)-1 != expected {
+			t.Errorf("seed %d: expected a path of %d steps but got %d", seed, expected, len(path)-1)
+		}
+
+		if path[len(path)-1] != start {
+			t.Errorf("seed %d: expected path to end at start, got %v", seed, path[len(path)-1])
+		}
+	}
+}
+
+func BenchmarkBFS(b *testing.B) {
+	a := benchmarkArena(b, 300)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		adapter := arena.NewAdapter(a, a.FinishCoordinate())
+		s := NewSolver[arena.Coordinate](AlgorithmBFS, a.StartCoordinate(), adapter)
+		if len(s.Walk()) == 0 {
+			b.Fatal("expected a path")
+		}
+	}
+}
@@ -1,7 +0,0 @@
-package pathfind
-
-type candidate[T comparable] struct {
-	coord  T
-	parent *candidate[T]
-	cost   int
-}
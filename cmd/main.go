@@ -1,29 +1,43 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"log"
 	"os"
+	"path/filepath"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/tmw/pathfind"
 	"github.com/tmw/pathfind/pkg/arena"
+	"github.com/tmw/pathfind/pkg/graph"
 )
 
 var (
 	filename  string
 	algorithm string
+	front     string
+	partial   bool
+	avoid     string
 	verbose   bool
 
+	// solve between named nodes of a graph file instead of an arena
+	graphFile  string
+	from       string
+	to         string
+	undirected bool
+
 	// configure map symbols
 	symbolNonWalkable string
 	symbolWalkable    string
 	symbolStart       string
 	symbolFinish      string
 	symbolPath        string
+	symbolKeys        string
 )
 
 func init() {
@@ -33,15 +47,58 @@ func init() {
 	flag.StringVar(&symbolStart, "symbolStart", "", "symbol for tile of type start")
 	flag.StringVar(&symbolFinish, "symbolFinish", "", "symbol for tile of type finish")
 	flag.StringVar(&symbolPath, "symbolPath", "", "symbol for tile of type path")
-	flag.StringVar(&algorithm, "algorithm", "astar", "algorithm to use. either astar or bfs are supported")
+	flag.StringVar(
+		&symbolKeys,
+		"symbolKeys",
+		"",
+		"lowercase letters marking keys, opening the doors of the same uppercase letter",
+	)
+	flag.StringVar(
+		&algorithm,
+		"algorithm",
+		"astar",
+		"algorithm to use. either astar, parallel-astar or bfs are supported",
+	)
+	flag.StringVar(
+		&front,
+		"frontier",
+		"",
+		"frontier to use. either fifo, binary, d-ary, pairing or radix. defaults to the one of the algorithm",
+	)
+	flag.BoolVar(&partial, "partial", false, "when the finish can't be reached, show the path to the cell closest to it")
+	flag.StringVar(&avoid, "avoid", "", "cells to keep out of, as x,y pairs separated by semicolons, e.g. \"3,1;4,2\"")
 	flag.BoolVar(&verbose, "verbose", true, "print runtime information")
+	flag.StringVar(&graphFile, "graph", "", "path of a .dot, .gv, .csv or .json graph to solve instead of an arena")
+	flag.StringVar(&from, "from", "", "node of the graph to start from")
+	flag.StringVar(&to, "to", "", "node of the graph to finish at")
+	flag.BoolVar(
+		&undirected,
+		"undirected",
+		false,
+		"read the edges of a .csv graph as undirected. for .json graphs, set \"directed\" to false instead",
+	)
 }
 
 func main() {
 	flag.Parse()
 
-	if !slices.Contains([]string{"astar", "bfs"}, algorithm) {
-		log.Fatal("provided algorithm not supported, must be any of: astar, bfs")
+	if !slices.Contains([]string{"astar", "parallel-astar", "bfs"}, algorithm) {
+		log.Fatal("provided algorithm not supported, must be any of: astar, parallel-astar, bfs")
+	}
+
+	if front != "" && !slices.Contains([]string{"fifo", "binary", "d-ary", "pairing", "radix"}, front) {
+		log.Fatal("provided frontier not supported, must be any of: fifo, binary, d-ary, pairing, radix")
+	}
+
+	if front == "fifo" && algorithm == "astar" {
+		log.Fatal("the fifo frontier ignores priorities, so it can't be used with astar")
+	}
+
+	if len(graphFile) > 0 {
+		if err := solveGraph(); err != nil {
+			log.Fatal(err)
+		}
+		return
 	}
 
 	contents, err := getContents()
@@ -57,11 +114,70 @@ func main() {
 }
 
 func getAlgorithm() pathfind.Algorithm {
-	if algorithm == "bfs" {
+	switch algorithm {
+	case "bfs":
 		return pathfind.AlgorithmBFS
+
+	case "parallel-astar":
+		return pathfind.AlgorithmParallelAStar
+
+	default:
+		return pathfind.AlgorithmAStar
+	}
+}
+
+func getFrontier() pathfind.FrontierKind {
+	switch front {
+	case "fifo":
+		return pathfind.FrontierFIFO
+
+	case "binary":
+		return pathfind.FrontierBinaryHeap
+
+	case "d-ary":
+		return pathfind.FrontierDAryHeap
+
+	case "pairing":
+		return pathfind.FrontierPairingHeap
+
+	case "radix":
+		return pathfind.FrontierRadixHeap
+
+	default:
+		return pathfind.FrontierDefault
+	}
+}
+
+// returns the cells passed through -avoid.
+func getAvoided() ([]arena.Coordinate, error) {
+	avoided := []arena.Coordinate{}
+	for _, s := range strings.Split(avoid, ";") {
+		if strings.TrimSpace(s) == "" {
+			continue
+		}
+
+		c, err := arena.ParseCoordinate(s)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", err, s)
+		}
+		avoided = append(avoided, c)
+	}
+	return avoided, nil
+}
+
+// returns an adapter towards finish, keeping out of the cells passed
+// through -avoid.
+func newAdapter(
+	a *arena.Arena,
+	finish arena.Coordinate,
+	avoided []arena.Coordinate,
+) pathfind.Adapter[arena.Coordinate] {
+	adapter := arena.NewAdapter(a, finish)
+	if len(avoided) == 0 {
+		return adapter
 	}
 
-	return pathfind.AlgorithmAStar
+	return pathfind.NewConstraints[arena.Coordinate](adapter).ForbidNode(avoided...)
 }
 
 func assignSymbols() {
@@ -84,6 +200,10 @@ func assignSymbols() {
 	if len(symbolPath) > 0 {
 		arena.SymbolPath = symbolPath
 	}
+
+	if len(symbolKeys) > 0 {
+		arena.SymbolKeys = symbolKeys
+	}
 }
 
 func getContents() (string, error) {
@@ -124,28 +244,34 @@ func solve(input string) error {
 		return err
 	}
 
+	avoided, err := getAvoided()
+	if err != nil {
+		return err
+	}
+
+	if len(a.Waypoints()) > 0 {
+		return solveRoute(a, avoided)
+	}
+
+	if a.HasDoors() {
+		// nodes carry the keys collected so far, so avoiding a cell would
+		// take forbidding it for every combination of keys.
+		if len(avoided) > 0 {
+			return errors.New("-avoid is not supported for arenas with doors")
+		}
+
+		return solveKeys(a)
+	}
+
 	s := pathfind.NewSolver[arena.Coordinate](
 		getAlgorithm(),
 		a.StartCoordinate(),
-		&pathfind.FuncAdapter[arena.Coordinate]{
-			NeighboursFn: func(c arena.Coordinate) []arena.Coordinate {
-				n := a.NeighboursOfCoordinate(c)
-				return slices.DeleteFunc(n, func(c arena.Coordinate) bool {
-					return a.CellTypeForCoordinate(c) == arena.CellTypeNonWalkable
-				})
-			},
-
-			CostToFinishFn: func(c arena.Coordinate) int {
-				return c.DistanceTo(a.FinishCoordinate())
-			},
-
-			IsFinishFn: func(c arena.Coordinate) bool {
-				return a.CellTypeForCoordinate(c) == arena.CellTypeFinish
-			},
-		},
+		newAdapter(a, a.FinishCoordinate(), avoided),
 	)
 
 	s.MaxCost = 50
+	s.Frontier = getFrontier()
+	s.AllowPartial = partial
 	start := time.Now()
 	path := s.Walk()
 	duration := time.Since(start)
@@ -164,9 +290,143 @@ func solve(input string) error {
 		}
 
 		fmt.Printf("used algorithm: \t\t%s\n", algorithm)
+		fmt.Printf("status: \t\t\t%s\n", s.Status())
 		fmt.Printf("duration: \t\t\t%s\n", duration)
 		fmt.Printf("unique candidates visited: \t%d\n", len(candidates))
 	}
 
 	return nil
 }
+
+// solves a route from start to finish, passing through the numbered
+// waypoints of the arena in ascending order.
+func solveRoute(a *arena.Arena, avoided []arena.Coordinate) error {
+	waypoints := append([]arena.Coordinate{a.StartCoordinate()}, a.Waypoints()...)
+	waypoints = append(waypoints, a.FinishCoordinate())
+
+	start := time.Now()
+	route, err := pathfind.SolveRoute(
+		getAlgorithm(),
+		waypoints,
+		func(finish arena.Coordinate) pathfind.Adapter[arena.Coordinate] {
+			return newAdapter(a, finish, avoided)
+		},
+	)
+	duration := time.Since(start)
+
+	if err != nil {
+		return err
+	}
+
+	a.RenderWithPath(os.Stdout, route.Path)
+	fmt.Print("\n\n")
+
+	if verbose {
+		fmt.Printf("used algorithm: \t\t%s\n", algorithm)
+		fmt.Printf("duration: \t\t\t%s\n", duration)
+
+		for idx, leg := range route.Legs {
+			fmt.Printf("cost of leg %d: \t\t\t%d\n", idx+1, leg.Cost)
+		}
+
+		fmt.Printf("total cost: \t\t\t%d\n", route.Cost)
+	}
+
+	return nil
+}
+
+// solves an arena with doors, collecting keys along the way where needed.
+func solveKeys(a *arena.Arena) error {
+	s := pathfind.NewSolver[arena.KeyState](
+		getAlgorithm(),
+		arena.KeyState{Coordinate: a.StartCoordinate()},
+		arena.NewKeyAdapter(a),
+	)
+	s.Frontier = getFrontier()
+
+	start := time.Now()
+	path := s.Walk()
+	duration := time.Since(start)
+
+	a.RenderWithKeyPath(os.Stdout, path)
+	fmt.Print("\n\n")
+
+	if verbose {
+		fmt.Printf("used algorithm: \t\t%s\n", algorithm)
+		fmt.Printf("duration: \t\t\t%s\n", duration)
+		fmt.Printf("path length: \t\t\t%d\n", len(path))
+	}
+
+	return nil
+}
+
+// reads the graph passed through -graph, picking the format by extension.
+func readGraph() (*graph.Graph[string], error) {
+	file, err := os.Open(graphFile)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	switch strings.ToLower(filepath.Ext(graphFile)) {
+	case ".dot", ".gv":
+		return graph.ReadDOT(file)
+
+	case ".csv":
+		direction := graph.Directed
+		if undirected {
+			direction = graph.Undirected
+		}
+		return graph.ReadCSV(file, direction)
+
+	case ".json":
+		return graph.ReadJSON(file)
+
+	default:
+		return nil, fmt.Errorf("unsupported graph format %q, must be any of: .dot, .gv, .csv, .json", filepath.Ext(graphFile))
+	}
+}
+
+// solves the graph passed through -graph between the nodes passed through
+// -from and -to.
+func solveGraph() error {
+	g, err := readGraph()
+	if err != nil {
+		return err
+	}
+
+	for _, n := range []string{from, to} {
+		if !g.HasNode(n) {
+			return fmt.Errorf("node %q not found in %s", n, graphFile)
+		}
+	}
+
+	s := pathfind.NewSolver[string](getAlgorithm(), from, g.Target(to))
+	s.Frontier = getFrontier()
+	s.AllowPartial = partial
+
+	start := time.Now()
+	path := s.Walk()
+	duration := time.Since(start)
+
+	// paths run from finish to start
+	cost := 0
+	for idx := len(path) - 1; idx > 0; idx-- {
+		cost += g.EdgeCost(path[idx], path[idx-1])
+	}
+
+	if len(path) > 0 {
+		slices.Reverse(path)
+		fmt.Print(strings.Join(path, " -> "))
+		fmt.Print("\n\n")
+	}
+
+	if verbose {
+		fmt.Printf("used algorithm: \t\t%s\n", algorithm)
+		fmt.Printf("status: \t\t\t%s\n", s.Status())
+		fmt.Printf("duration: \t\t\t%s\n", duration)
+		fmt.Printf("total cost: \t\t\t%d\n", cost)
+	}
+
+	return nil
+}
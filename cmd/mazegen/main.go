@@ -0,0 +1,108 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/tmw/pathfind/pkg/arena"
+	"github.com/tmw/pathfind/pkg/maze"
+)
+
+var (
+	algorithm string
+	width     int
+	height    int
+	density   float64
+	seed      int64
+	output    string
+)
+
+var algorithms = map[string]maze.Algorithm{
+	"backtracker": maze.AlgorithmRecursiveBacktracker,
+	"prim":        maze.AlgorithmPrim,
+	"kruskal":     maze.AlgorithmKruskal,
+	"wilson":      maze.AlgorithmWilson,
+}
+
+var levels = map[string]func(width, height int, density float64, seed int64) (maze.Level, error){
+	"noise": maze.Noise,
+	"cave":  maze.Cave,
+}
+
+func init() {
+	flag.StringVar(
+		&algorithm,
+		"algorithm",
+		"backtracker",
+		"algorithm to use. either backtracker, prim, kruskal, wilson, noise or cave are supported",
+	)
+	flag.IntVar(&width, "width", 14, "number of rooms from left to right, or cells for noise and cave")
+	flag.IntVar(&height, "height", 8, "number of rooms from top to bottom, or cells for noise and cave")
+	flag.Float64Var(&density, "density", 0.45, "share of cells to turn into obstacles for noise and cave")
+	flag.Int64Var(&seed, "seed", 0, "seed for generating the maze, picked at random when 0")
+	flag.StringVar(&output, "output", "", "path of the file to write, writes to stdout when empty")
+}
+
+func main() {
+	flag.Parse()
+
+	// the seed goes to stderr, so that random mazes can be reproduced
+	// without mixing it into the maze itself.
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+		fmt.Fprintf(os.Stderr, "seed: %d\n", seed)
+	}
+
+	a, err := generate()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := write(func(w io.Writer) {
+		a.Render(w)
+		fmt.Fprintln(w)
+	}); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func generate() (*arena.Arena, error) {
+	if alg, found := algorithms[algorithm]; found {
+		return maze.Generate(alg, width, height, seed)
+	}
+
+	if generateLevel, found := levels[algorithm]; found {
+		level, err := generateLevel(width, height, density, seed)
+		if err != nil {
+			return nil, err
+		}
+
+		fmt.Fprintf(os.Stderr, "optimal path length: %d\n", level.OptimalPathLength)
+		return level.Arena, nil
+	}
+
+	return nil, errors.New(
+		"provided algorithm not supported, must be any of: backtracker, prim, kruskal, wilson, noise, cave",
+	)
+}
+
+func write(render func(io.Writer)) error {
+	if len(output) == 0 {
+		render(os.Stdout)
+		return nil
+	}
+
+	file, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	render(file)
+	return nil
+}
@@ -0,0 +1,167 @@
+package pathfind
+
+import "sync"
+
+// a directed edge between two neighbours.
+type edge[T comparable] struct {
+	from, to T
+}
+
+// an overlay on an adapter that forbids nodes and edges, and makes edges
+// more expensive, without touching the adapter itself. Penalties only add
+// to edge costs, so the estimates of the adapter stay admissible.
+//
+// Constraints implements the optional interfaces an adapter can, falling back
+// on something sensible when the adapter it wraps doesn't. As an Unwrapper,
+// solvers only make use of those the wrapped adapter implements.
+type Constraints[T comparable] struct {
+	inner     Adapter[T]
+	nodes     map[T]struct{}
+	edges     map[edge[T]]struct{}
+	penalties map[edge[T]]int
+
+	// numbers handed out by Index when the inner adapter is no Indexer
+	mu      sync.Mutex
+	numbers map[T]int
+}
+
+func NewConstraints[T comparable](adapter Adapter[T]) *Constraints[T] {
+	return &Constraints[T]{
+		inner:     adapter,
+		nodes:     make(map[T]struct{}),
+		edges:     make(map[edge[T]]struct{}),
+		penalties: make(map[edge[T]]int),
+	}
+}
+
+// keeps walkers from entering the given nodes.
+func (c *Constraints[T]) ForbidNode(nodes ...T) *Constraints[T] {
+	for _, n := range nodes {
+		c.nodes[n] = struct{}{}
+	}
+	return c
+}
+
+// keeps walkers from moving from -> to. Edges are directed, so forbidding
+// both directions takes two calls.
+func (c *Constraints[T]) ForbidEdge(from, to T) *Constraints[T] {
+	c.edges[edge[T]{from: from, to: to}] = struct{}{}
+	return c
+}
+
+// adds penalty to the cost of moving from -> to, on top of any penalty
+// added before.
+func (c *Constraints[T]) Penalize(from, to T, penalty int) *Constraints[T] {
+	c.penalties[edge[T]{from: from, to: to}] += penalty
+	return c
+}
+
+func (c *Constraints[T]) Neighbours(n T) []T {
+	neighbours := []T{}
+	for _, next := range c.inner.Neighbours(n) {
+		if c.allows(n, next) {
+			neighbours = append(neighbours, next)
+		}
+	}
+	return neighbours
+}
+
+func (c *Constraints[T]) CostToFinish(n T) int {
+	return c.inner.CostToFinish(n)
+}
+
+func (c *Constraints[T]) IsFinish(n T) bool {
+	return c.inner.IsFinish(n)
+}
+
+func (c *Constraints[T]) EdgeCost(from, to T) int {
+	return edgeCost(c.inner, from, to) + c.penalties[edge[T]{from: from, to: to}]
+}
+
+func (c *Constraints[T]) Unwrap() Adapter[T] {
+	return c.inner
+}
+
+// numbers nodes the way the inner adapter does. Otherwise nodes are numbered
+// in the order they are first asked about, which takes a lookup per call.
+func (c *Constraints[T]) Index(n T) int {
+	if indexer, ok := c.inner.(Indexer[T]); ok {
+		return indexer.Index(n)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.numbers == nil {
+		c.numbers = make(map[T]int)
+	}
+
+	number, found := c.numbers[n]
+	if !found {
+		number = len(c.numbers)
+		c.numbers[n] = number
+	}
+	return number
+}
+
+// returns the nodes that are allowed to move to n, among the predecessors
+// of the inner adapter. Without those, no predecessors are known at all.
+func (c *Constraints[T]) Predecessors(n T) []T {
+	predecessors := []T{}
+
+	reversible, ok := c.inner.(ReversibleAdapter[T])
+	if !ok {
+		return predecessors
+	}
+
+	for _, prev := range reversible.Predecessors(n) {
+		if c.allows(prev, n) {
+			predecessors = append(predecessors, prev)
+		}
+	}
+	return predecessors
+}
+
+// estimates the cost of moving from -> to the way the inner adapter does.
+// Otherwise it estimates 0, which never overestimates.
+func (c *Constraints[T]) Estimate(from, to T) int {
+	if estimator, ok := c.inner.(Estimator[T]); ok {
+		return estimator.Estimate(from, to)
+	}
+
+	return 0
+}
+
+// returns the allowed transitions of the inner adapter, with penalties added
+// to their costs. When the inner adapter doesn't label its transitions, they
+// are made up from the neighbours and edge costs instead.
+func (c *Constraints[T]) Transitions(n T) []Transition[T] {
+	transitions := []Transition[T]{}
+
+	actionAdapter, ok := labelsActions(c.inner)
+	if !ok {
+		for _, next := range c.Neighbours(n) {
+			transitions = append(transitions, Transition[T]{State: next, Cost: c.EdgeCost(n, next)})
+		}
+		return transitions
+	}
+
+	for _, t := range actionAdapter.Transitions(n) {
+		if c.allows(n, t.State) {
+			t.Cost += c.penalties[edge[T]{from: n, to: t.State}]
+			transitions = append(transitions, t)
+		}
+	}
+	return transitions
+}
+
+// reports whether moving from -> to is neither into a forbidden node nor
+// along a forbidden edge.
+func (c *Constraints[T]) allows(from, to T) bool {
+	if _, forbidden := c.nodes[to]; forbidden {
+		return false
+	}
+
+	_, forbidden := c.edges[edge[T]{from: from, to: to}]
+	return !forbidden
+}
@@ -0,0 +1,231 @@
+package pathfind
+
+import (
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/tmw/pathfind/pkg/arena"
+)
+
+// two equally long ways around the wall, through the top or bottom row.
+const twoWays = `
+.....
+S###F
+.....`
+
+func solveConstrained(t *testing.T, configure func(*Constraints[arena.Coordinate])) []arena.Coordinate {
+	t.Helper()
+
+	a, err := arena.Parse(twoWays)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewConstraints[arena.Coordinate](arena.NewAdapter(a, a.FinishCoordinate()))
+	configure(c)
+
+	s := NewSolver[arena.Coordinate](AlgorithmAStar, a.StartCoordinate(), c)
+	return s.Walk()
+}
+
+func usesRow(path []arena.Coordinate, y int) bool {
+	for _, c := range path {
+		for x := 0; x < 5; x++ {
+			if c == arena.NewCoordinate(x, y) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestConstraintsForbidNode(t *testing.T) {
+	path := solveConstrained(t, func(c *Constraints[arena.Coordinate]) {
+		c.ForbidNode(arena.NewCoordinate(2, 0))
+	})
+
+	if len(path) != 7 || usesRow(path, 0) {
+		t.Errorf("expected a path of 7 cells along the bottom row, got %v", path)
+	}
+
+	path = solveConstrained(t, func(c *Constraints[arena.Coordinate]) {
+		c.ForbidNode(arena.NewCoordinate(2, 0), arena.NewCoordinate(2, 2))
+	})
+
+	if len(path) != 0 {
+		t.Errorf("expected no path with both ways blocked, got %v", path)
+	}
+}
+
+func TestConstraintsForbidEdge(t *testing.T) {
+	path := solveConstrained(t, func(c *Constraints[arena.Coordinate]) {
+		c.ForbidEdge(arena.NewCoordinate(2, 2), arena.NewCoordinate(3, 2))
+	})
+
+	if len(path) != 7 || usesRow(path, 2) {
+		t.Errorf("expected a path of 7 cells along the top row, got %v", path)
+	}
+
+	// only the opposite direction is forbidden, which doesn't matter here.
+	path = solveConstrained(t, func(c *Constraints[arena.Coordinate]) {
+		c.ForbidEdge(arena.NewCoordinate(3, 0), arena.NewCoordinate(2, 0))
+		c.ForbidEdge(arena.NewCoordinate(3, 2), arena.NewCoordinate(2, 2))
+	})
+
+	if len(path) != 7 {
+		t.Errorf("expected a path of 7 cells, got %v", path)
+	}
+}
+
+func TestConstraintsPenalize(t *testing.T) {
+	path := solveConstrained(t, func(c *Constraints[arena.Coordinate]) {
+		c.Penalize(arena.NewCoordinate(1, 0), arena.NewCoordinate(2, 0), 5)
+	})
+
+	if len(path) != 7 || usesRow(path, 0) {
+		t.Errorf("expected a path of 7 cells along the bottom row, got %v", path)
+	}
+}
+
+func TestConstraintsLeaveArenaUntouched(t *testing.T) {
+	a, err := arena.Parse(twoWays)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blocked := arena.NewCoordinate(2, 0)
+	c := NewConstraints[arena.Coordinate](arena.NewAdapter(a, a.FinishCoordinate()))
+	c.ForbidNode(blocked)
+
+	if !a.IsWalkable(blocked) {
+		t.Error("expected forbidden cell to stay walkable in the arena")
+	}
+}
+
+func TestConstraintsForwardIndexer(t *testing.T) {
+	a, err := arena.Parse(twoWays)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewConstraints[arena.Coordinate](arena.NewAdapter(a, a.FinishCoordinate()))
+	s := NewSolver[arena.Coordinate](AlgorithmAStar, a.StartCoordinate(), c)
+	if _, ok := s.Visited.(*bitsetVisitedSet[arena.Coordinate]); !ok {
+		t.Errorf("expected a bitset for constraints on an indexable adapter, got %T", s.Visited)
+	}
+
+	f := NewSolver[string](AlgorithmAStar, "s", NewConstraints[string](inconsistentGraph()))
+	if _, ok := f.Visited.(mapVisitedSet[string]); !ok {
+		t.Errorf("expected a map for constraints on an adapter without Indexer, got %T", f.Visited)
+	}
+
+	if len(f.Walk()) == 0 {
+		t.Error("expected a path for constraints on an adapter without Indexer")
+	}
+}
+
+func TestConstraintsPredecessors(t *testing.T) {
+	a, err := arena.Parse(twoWays)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewConstraints[arena.Coordinate](arena.NewAdapter(a, a.FinishCoordinate())).
+		ForbidNode(arena.NewCoordinate(1, 0)).
+		ForbidEdge(arena.NewCoordinate(0, 2), arena.NewCoordinate(0, 1))
+
+	if _, ok := optional[ReversibleAdapter[arena.Coordinate]](c); !ok {
+		t.Fatal("expected constraints on a reversible adapter to be reversible")
+	}
+
+	// the start is reached from above and below, one of which is forbidden.
+	if actual := c.Predecessors(a.StartCoordinate()); !slices.Equal(actual, []arena.Coordinate{arena.NewCoordinate(0, 0)}) {
+		t.Errorf("expected only 0,0 to lead to the start, got %v", actual)
+	}
+
+	if actual := c.Predecessors(arena.NewCoordinate(1, 0)); len(actual) != 0 {
+		t.Errorf("expected nothing to lead into a forbidden node, got %v", actual)
+	}
+
+	if _, ok := optional[ReversibleAdapter[string]](NewConstraints[string](inconsistentGraph())); ok {
+		t.Error("expected constraints on an adapter without Predecessors not to be reversible")
+	}
+}
+
+func TestConstraintsFallBackWithoutOptionalInterfaces(t *testing.T) {
+	c := NewConstraints[string](inconsistentGraph())
+
+	if c.Index("s") != c.Index("s") || c.Index("s") == c.Index("a") {
+		t.Errorf("expected stable and unique numbers, got %d for s and %d for a", c.Index("s"), c.Index("a"))
+	}
+
+	if actual := c.Predecessors("a"); len(actual) != 0 {
+		t.Errorf("expected no known predecessors, got %v", actual)
+	}
+
+	if actual := c.Estimate("s", "a"); actual != 0 {
+		t.Errorf("expected an estimate of 0, got %d", actual)
+	}
+}
+
+// counts the estimates handed out, to tell whether searches make use of them.
+type countedEstimates struct {
+	*arena.Adapter
+	count atomic.Int64
+}
+
+func (c *countedEstimates) Estimate(from, to arena.Coordinate) int {
+	c.count.Add(1)
+	return c.Adapter.Estimate(from, to)
+}
+
+func TestConstraintsInBatch(t *testing.T) {
+	a, err := arena.Parse("S....\n.###.\n....F")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inner := &countedEstimates{Adapter: arena.NewAdapter(a, a.FinishCoordinate())}
+	c := NewConstraints[arena.Coordinate](inner).ForbidNode(arena.NewCoordinate(2, 0))
+
+	results := NewBatch[arena.Coordinate](AlgorithmAStar, c).Solve([]Query[arena.Coordinate]{
+		{Start: a.StartCoordinate(), Finish: arena.NewCoordinate(4, 0), HasFinish: true},
+	})
+
+	// around the wall through the bottom row, as the top row is blocked.
+	if results[0].Cost != 8 || slices.Contains(results[0].Path, arena.NewCoordinate(2, 0)) {
+		t.Errorf("expected a path of cost 8 through the bottom row, got %v costing %d", results[0].Path, results[0].Cost)
+	}
+
+	if inner.count.Load() == 0 {
+		t.Error("expected the estimates of the inner adapter to guide the search")
+	}
+}
+
+func TestConstraintsForwardActions(t *testing.T) {
+	// without emptying the 3 litre jug halfway, starting with it is shortest.
+	c := NewConstraints[jugs](jugPuzzle()).ForbidEdge(jugs{3, 2}, jugs{0, 2})
+
+	s := NewSolver[jugs](AlgorithmAStar, jugs{}, c)
+	path := s.Walk()
+	actions := s.Actions()
+
+	if len(path) != 9 || len(actions) != 8 || actions[len(actions)-1] != "fill 3" {
+		t.Fatalf("expected 8 actions starting with fill 3, got %v", actions)
+	}
+
+	penalized := NewConstraints[jugs](jugPuzzle()).Penalize(jugs{}, jugs{3, 0}, 10)
+	for _, tr := range penalized.Transitions(jugs{}) {
+		if tr.State == (jugs{3, 0}) && tr.Cost != 11 {
+			t.Errorf("expected filling the 3 litre jug to cost 11, got %d", tr.Cost)
+		}
+	}
+
+	r := NewSolver[string](AlgorithmAStar, "s", NewConstraints[string](inconsistentGraph()))
+	r.Walk()
+
+	if r.Actions() != nil {
+		t.Errorf("expected no actions for constraints on an adapter without transitions, got %v", r.Actions())
+	}
+}
@@ -0,0 +1,74 @@
+package pathfind
+
+import (
+	"github.com/tmw/pathfind/pkg/prioqueue"
+)
+
+type shortestPaths[T comparable] struct {
+	dist   map[T]int
+	parent map[T]T
+
+	// nodes in the order they were settled, closest first.
+	order []T
+}
+
+// runs Dijkstra's algorithm from source over everything reachable from it,
+// using the adapter's edge costs. Used for preprocessing rather than solving,
+// so it does not publish events or respect a MaxCost.
+func dijkstra[T comparable](adapter Adapter[T], source T) shortestPaths[T] {
+	return searchShortestPaths(
+		[]T{source},
+		adapter.Neighbours,
+		func(from, to T) int { return edgeCost(adapter, from, to) },
+		nil,
+	)
+}
+
+// runs Dijkstra's algorithm from all sources at once, until running out of
+// nodes or settling a node for which stop returns true, when given.
+func searchShortestPaths[T comparable](
+	sources []T,
+	neighbours func(T) []T,
+	cost func(from, to T) int,
+	stop func(T) bool,
+) shortestPaths[T] {
+	sp := shortestPaths[T]{
+		dist:   make(map[T]int),
+		parent: make(map[T]T),
+		order:  []T{},
+	}
+
+	settled := make(map[T]struct{})
+	candidates := prioqueue.New[T]()
+	for _, source := range sources {
+		sp.dist[source] = 0
+		candidates.Push(source, 0)
+	}
+
+	for candidates.Len() > 0 {
+		current := candidates.Pop()
+		if _, found := settled[current]; found {
+			continue
+		}
+
+		settled[current] = struct{}{}
+		sp.order = append(sp.order, current)
+
+		if stop != nil && stop(current) {
+			break
+		}
+
+		for _, n := range neighbours(current) {
+			c := sp.dist[current] + cost(current, n)
+			if known, found := sp.dist[n]; found && known <= c {
+				continue
+			}
+
+			sp.dist[n] = c
+			sp.parent[n] = current
+			candidates.Push(n, c)
+		}
+	}
+
+	return sp
+}
@@ -12,15 +12,43 @@ type EventCandidateVisited[T comparable] struct {
 	CandidateID T
 }
 
+// published by astar when it finds a cheaper route to a node it reached
+// before, either still on the frontier or, with ReopenClosed, expanded.
+type EventCandidateUpdated[T comparable] struct {
+	CandidateID T
+	Parent      T
+}
+
 type EventFinishReached[T comparable] struct {
 	Path []T
+
+	// the actions taken along Path, when the adapter implements
+	// ActionAdapter. Actions[i] leads from Path[i+1] to Path[i].
+	Actions []string
+}
+
+// published by astar when CheckConsistency is enabled, for every edge along
+// which CostToFinish drops by more than the cost of the edge.
+type EventHeuristicInconsistent[T comparable] struct {
+	From T
+	To   T
+}
+
+// published with AllowPartial when the finish was not reached, holding the
+// path to the expanded node estimated closest to it instead.
+type EventPartialPath[T comparable] struct {
+	Path    []T
+	Actions []string
 }
 
 type EventUnsolvable struct{}
 type EventMaxCostReached struct{}
 
-func (e EventCandidateAdded[T]) event()   {}
-func (e EventCandidateVisited[T]) event() {}
-func (e EventFinishReached[T]) event()    {}
-func (e EventUnsolvable) event()          {}
-func (e EventMaxCostReached) event()      {}
+func (e EventCandidateAdded[T]) event()        {}
+func (e EventCandidateVisited[T]) event()      {}
+func (e EventCandidateUpdated[T]) event()      {}
+func (e EventFinishReached[T]) event()         {}
+func (e EventHeuristicInconsistent[T]) event() {}
+func (e EventPartialPath[T]) event()           {}
+func (e EventUnsolvable) event()               {}
+func (e EventMaxCostReached) event()           {}
@@ -13,13 +13,34 @@ function small() {
         -filename="examples/small.txt"
 }
 
+function waypoints() {
+    go run cmd/main.go \
+        -filename="examples/waypoints.txt"
+}
+
+function keys() {
+    go run cmd/main.go \
+        -filename="examples/keys.txt" \
+        -symbolKeys="ab"
+}
+
+function graph() {
+    go run cmd/main.go \
+        -graph="examples/roads.dot" \
+        -from="Haarlem" \
+        -to="Eindhoven"
+}
+
 function help() {
-    echo "Missing agrument.\n\nUsage: examples/example.sh <emoji|small>"
+    echo "Missing agrument.\n\nUsage: examples/example.sh <emoji|small|waypoints|keys|graph>"
 }
 
 case "$1" in
     "emoji") emoji ;;
     "small") small ;;
+    "waypoints") waypoints ;;
+    "keys") keys ;;
+    "graph") graph ;;
 
     *) help ;;
 esac
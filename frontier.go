@@ -0,0 +1,62 @@
+package pathfind
+
+import (
+	"github.com/tmw/pathfind/pkg/frontier"
+)
+
+type FrontierKind int
+
+const (
+	// lets the algorithm pick, a FIFO queue for BFS and a binary heap for
+	// the A* variants.
+	FrontierDefault FrontierKind = iota
+
+	// ignores priorities, so AlgorithmAStar uses a binary heap instead.
+	FrontierFIFO
+	FrontierBinaryHeap
+
+	// a heap with four children per node.
+	FrontierDAryHeap
+	FrontierPairingHeap
+
+	// only suits monotone priorities, such as A* with a consistent heuristic.
+	// Reopened nodes break that, so AlgorithmAStar uses a binary heap instead
+	// when ReopenClosed is set.
+	FrontierRadixHeap
+)
+
+// returns a frontier of the given kind, or of fallback for FrontierDefault,
+// holding the nodeSlab index of every queued node.
+func newFrontier[T comparable](kind, fallback FrontierKind) frontier.Frontier[T, int, int] {
+	if kind == FrontierDefault {
+		kind = fallback
+	}
+
+	switch kind {
+	case FrontierFIFO:
+		return frontier.NewFIFO[T, int, int]()
+
+	case FrontierDAryHeap:
+		return frontier.NewDAry[T, int, int](4)
+
+	case FrontierPairingHeap:
+		return frontier.NewPairing[T, int, int]()
+
+	case FrontierRadixHeap:
+		return frontier.NewRadix[T, int, int]()
+
+	default:
+		return frontier.NewBinary[T, int, int]()
+	}
+}
+
+// returns the kind of frontier A* can use without giving up optimality. A
+// FIFO queue ignores priorities altogether, and a radix heap can't order the
+// lower priorities that reopened nodes come back with.
+func astarFrontier(kind FrontierKind, reopenClosed bool) FrontierKind {
+	if kind == FrontierFIFO || (kind == FrontierRadixHeap && reopenClosed) {
+		return FrontierBinaryHeap
+	}
+
+	return kind
+}
@@ -0,0 +1,117 @@
+package pathfind
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/tmw/pathfind/pkg/arena"
+)
+
+var frontierKinds = []FrontierKind{
+	FrontierBinaryHeap,
+	FrontierDAryHeap,
+	FrontierPairingHeap,
+	FrontierRadixHeap,
+}
+
+func TestFrontierKindsAreOptimal(t *testing.T) {
+	for _, algorithm := range []Algorithm{AlgorithmAStar, AlgorithmParallelAStar} {
+		for _, kind := range frontierKinds {
+			algorithm, kind := algorithm, kind
+			t.Run(fmt.Sprintf("algorithm %d with frontier %d", algorithm, kind), func(t *testing.T) {
+				for seed := int64(0); seed < 10; seed++ {
+					adapter := randomMaze(16, seed)
+					start := [2]int{0, 0}
+
+					expected, reachable := dijkstra[[2]int](adapter, start).dist[[2]int{15, 15}]
+
+					s := NewSolver[[2]int](algorithm, start, adapter)
+					s.Frontier = kind
+					path := s.Walk()
+
+					if !reachable {
+						if len(path) != 0 {
+							t.Errorf("seed %d: expected no path but got %v", seed, path)
+						}
+						continue
+					}
+
+					if actual := pathCost[[2]int](adapter, path); actual != expected {
+						t.Errorf("seed %d: expected path cost %d but got %d", seed, expected, actual)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestAStarFallsBackFromUnsuitableFrontiers(t *testing.T) {
+	t.Run("fifo", func(t *testing.T) {
+		for seed := int64(0); seed < 10; seed++ {
+			adapter := randomMaze(16, seed)
+			start := [2]int{0, 0}
+
+			expected, reachable := dijkstra[[2]int](adapter, start).dist[[2]int{15, 15}]
+			if !reachable {
+				continue
+			}
+
+			s := NewSolver[[2]int](AlgorithmAStar, start, adapter)
+			s.Frontier = FrontierFIFO
+
+			if actual := pathCost[[2]int](adapter, s.Walk()); actual != expected {
+				t.Errorf("seed %d: expected path cost %d but got %d", seed, expected, actual)
+			}
+		}
+	})
+
+	t.Run("radix with reopening", func(t *testing.T) {
+		if kind := astarFrontier(FrontierRadixHeap, true); kind != FrontierBinaryHeap {
+			t.Errorf("expected a binary heap when reopening, got %d", kind)
+		}
+
+		if kind := astarFrontier(FrontierRadixHeap, false); kind != FrontierRadixHeap {
+			t.Errorf("expected the radix heap to be kept without reopening, got %d", kind)
+		}
+	})
+}
+
+func TestBFSWithHeapFrontier(t *testing.T) {
+	for seed := int64(0); seed < 10; seed++ {
+		adapter := randomMaze(16, seed)
+		start := [2]int{0, 0}
+
+		fifo := NewSolver[[2]int](AlgorithmBFS, start, adapter)
+		heap := NewSolver[[2]int](AlgorithmBFS, start, adapter)
+		heap.Frontier = FrontierBinaryHeap
+
+		expected, actual := fifo.Walk(), heap.Walk()
+		if len(actual) != len(expected) {
+			t.Errorf("seed %d: expected a path of %d steps but got %d", seed, len(expected), len(actual))
+		}
+	}
+}
+
+func BenchmarkFrontierKinds(b *testing.B) {
+	a := benchmarkArena(b, 300)
+	names := map[FrontierKind]string{
+		FrontierBinaryHeap:  "binary",
+		FrontierDAryHeap:    "d-ary",
+		FrontierPairingHeap: "pairing",
+		FrontierRadixHeap:   "radix",
+	}
+
+	for _, kind := range frontierKinds {
+		kind := kind
+		b.Run(names[kind], func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				adapter := arena.NewAdapter(a, a.FinishCoordinate())
+				s := NewSolver[arena.Coordinate](AlgorithmAStar, a.StartCoordinate(), adapter)
+				s.Frontier = kind
+				if len(s.Walk()) == 0 {
+					b.Fatal("expected a path")
+				}
+			}
+		})
+	}
+}
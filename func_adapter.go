@@ -4,9 +4,24 @@ type FuncAdapter[T comparable] struct {
 	NeighboursFn   func(T) []T
 	CostToFinishFn func(T) int
 	IsFinishFn     func(T) bool
+
+	// optional, every step costs 1 when left empty
+	EdgeCostFn func(T, T) int
+
+	// optional, labels moves with actions. When set, NeighboursFn and
+	// EdgeCostFn can be left empty, as they follow from the transitions.
+	TransitionsFn func(T) []Transition[T]
 }
 
 func (a *FuncAdapter[T]) Neighbours(c T) []T {
+	if a.NeighboursFn == nil && a.TransitionsFn != nil {
+		neighbours := []T{}
+		for _, t := range a.TransitionsFn(c) {
+			neighbours = append(neighbours, t.State)
+		}
+		return neighbours
+	}
+
 	return a.NeighboursFn(c)
 }
 
@@ -17,3 +32,31 @@ func (a *FuncAdapter[T]) CostToFinish(c T) int {
 func (a *FuncAdapter[T]) IsFinish(c T) bool {
 	return a.IsFinishFn(c)
 }
+
+func (a *FuncAdapter[T]) EdgeCost(from, to T) int {
+	if a.EdgeCostFn == nil && a.TransitionsFn != nil {
+		if t, found := cheapestTransition[T](a, from, to); found {
+			return t.Cost
+		}
+	}
+
+	if a.EdgeCostFn == nil {
+		return 1
+	}
+
+	return a.EdgeCostFn(from, to)
+}
+
+// returns the transitions of TransitionsFn, or unlabelled ones made up from
+// the neighbours and edge costs when it's left empty.
+func (a *FuncAdapter[T]) Transitions(c T) []Transition[T] {
+	if a.TransitionsFn != nil {
+		return a.TransitionsFn(c)
+	}
+
+	transitions := []Transition[T]{}
+	for _, n := range a.NeighboursFn(c) {
+		transitions = append(transitions, Transition[T]{State: n, Cost: a.EdgeCost(c, n)})
+	}
+	return transitions
+}
@@ -0,0 +1,113 @@
+package pathfind
+
+// adapters can optionally implement ReversibleAdapter to be searched
+// backwards, from the finish. CheckHeuristic uses it to find the true cost to
+// finish for all nodes in a single search.
+type ReversibleAdapter[T comparable] interface {
+	Adapter[T]
+
+	// to return the steps from which the given T can be reached
+	Predecessors(T) []T
+}
+
+type HeuristicViolationKind int
+
+const (
+	// CostToFinish overestimates the true cost to reach the finish, which
+	// breaks the optimality of astar.
+	ViolationInadmissible HeuristicViolationKind = iota
+
+	// CostToFinish drops by more than the cost of moving to a neighbour,
+	// which makes astar expand candidates before their cheapest path is
+	// known.
+	ViolationInconsistent
+)
+
+type HeuristicViolation[T comparable] struct {
+	Kind HeuristicViolationKind
+	Node T
+
+	// only set for ViolationInconsistent, the neighbour across the edge
+	// along which the estimate drops too much.
+	Neighbour T
+
+	// the estimate given by CostToFinish, and the bound it should stay
+	// within: the true cost to finish for ViolationInadmissible, or the cost
+	// of the edge plus the neighbour's estimate for ViolationInconsistent.
+	Heuristic int
+	Bound     int
+}
+
+// checks the CostToFinish of the adapter at the given sample nodes, returning
+// the samples at which it overestimates the true cost to finish or drops by
+// more than the cost of an edge to one of their neighbours.
+//
+// When the adapter implements ReversibleAdapter and the finishes are given,
+// the true costs are found by searching backwards from the finishes once.
+// Otherwise every sample gets searched forwards until reaching a finish.
+func CheckHeuristic[T comparable](adapter Adapter[T], samples []T, finishes ...T) []HeuristicViolation[T] {
+	violations := []HeuristicViolation[T]{}
+	trueCost := trueCostToFinish(adapter, finishes)
+
+	for _, n := range samples {
+		h := adapter.CostToFinish(n)
+
+		if cost, reachable := trueCost(n); reachable && h > cost {
+			violations = append(violations, HeuristicViolation[T]{
+				Kind:      ViolationInadmissible,
+				Node:      n,
+				Heuristic: h,
+				Bound:     cost,
+			})
+		}
+
+		for _, neighbour := range adapter.Neighbours(n) {
+			bound := edgeCost(adapter, n, neighbour) + adapter.CostToFinish(neighbour)
+			if h > bound {
+				violations = append(violations, HeuristicViolation[T]{
+					Kind:      ViolationInconsistent,
+					Node:      n,
+					Neighbour: neighbour,
+					Heuristic: h,
+					Bound:     bound,
+				})
+			}
+		}
+	}
+
+	return violations
+}
+
+// returns a function reporting the true cost to reach a finish from a node,
+// and whether a finish can be reached at all.
+func trueCostToFinish[T comparable](adapter Adapter[T], finishes []T) func(T) (int, bool) {
+	if reversible, ok := optional[ReversibleAdapter[T]](adapter); ok && len(finishes) > 0 {
+		backwards := searchShortestPaths(
+			finishes,
+			reversible.Predecessors,
+			func(from, to T) int { return edgeCost(adapter, to, from) },
+			nil,
+		)
+
+		return func(n T) (int, bool) {
+			cost, found := backwards.dist[n]
+			return cost, found
+		}
+	}
+
+	return func(n T) (int, bool) {
+		forwards := searchShortestPaths(
+			[]T{n},
+			adapter.Neighbours,
+			func(from, to T) int { return edgeCost(adapter, from, to) },
+			adapter.IsFinish,
+		)
+
+		last := forwards.order[len(forwards.order)-1]
+		if !adapter.IsFinish(last) {
+			return 0, false
+		}
+
+		return forwards.dist[last], true
+	}
+}
@@ -0,0 +1,93 @@
+package pathfind
+
+import (
+	"testing"
+)
+
+// a line of nodes 0 to 9 with the finish at 9, which can be walked both
+// ways. The estimate is exact, except where overridden.
+type lineAdapter struct {
+	estimates map[int]int
+}
+
+func (l *lineAdapter) Neighbours(n int) []int {
+	neighbours := []int{}
+	for _, next := range []int{n - 1, n + 1} {
+		if next >= 0 && next <= 9 {
+			neighbours = append(neighbours, next)
+		}
+	}
+	return neighbours
+}
+
+func (l *lineAdapter) Predecessors(n int) []int {
+	return l.Neighbours(n)
+}
+
+func (l *lineAdapter) CostToFinish(n int) int {
+	if estimate, found := l.estimates[n]; found {
+		return estimate
+	}
+	return 9 - n
+}
+
+func (l *lineAdapter) IsFinish(n int) bool {
+	return n == 9
+}
+
+// hides Predecessors, forcing forward searches.
+type forwardOnly struct {
+	Adapter[int]
+}
+
+func TestCheckHeuristic(t *testing.T) {
+	adapter := &lineAdapter{estimates: map[int]int{3: 8, 6: 0}}
+	samples := []int{0, 3, 5, 6, 9}
+
+	expected := []HeuristicViolation[int]{
+		{Kind: ViolationInadmissible, Node: 3, Heuristic: 8, Bound: 6},
+		{Kind: ViolationInconsistent, Node: 3, Neighbour: 4, Heuristic: 8, Bound: 6},
+		{Kind: ViolationInconsistent, Node: 5, Neighbour: 6, Heuristic: 4, Bound: 1},
+	}
+
+	variants := map[string]func() []HeuristicViolation[int]{
+		"searching backwards": func() []HeuristicViolation[int] {
+			return CheckHeuristic[int](adapter, samples, 9)
+		},
+		"searching forwards": func() []HeuristicViolation[int] {
+			return CheckHeuristic[int](forwardOnly{adapter}, samples)
+		},
+	}
+
+	for name, check := range variants {
+		t.Run(name, func(t *testing.T) {
+			actual := check()
+			if len(actual) != len(expected) {
+				t.Fatalf("expected %d violations but got %d: %+v", len(expected), len(actual), actual)
+			}
+
+			for idx := range actual {
+				if actual[idx] != expected[idx] {
+					t.Errorf("expected violation %+v but got %+v", expected[idx], actual[idx])
+				}
+			}
+		})
+	}
+}
+
+func TestAStarCheckConsistency(t *testing.T) {
+	s := NewSolver[int](AlgorithmAStar, 0, &lineAdapter{estimates: map[int]int{6: 0}})
+	s.CheckConsistency = true
+	s.Walk()
+
+	found := false
+	for _, e := range s.EventLog() {
+		if inconsistent, ok := e.(EventHeuristicInconsistent[int]); ok {
+			found = found || inconsistent == EventHeuristicInconsistent[int]{From: 5, To: 6}
+		}
+	}
+
+	if !found {
+		t.Error("expected EventHeuristicInconsistent to be published for the edge from 5 to 6")
+	}
+}
@@ -0,0 +1,228 @@
+package pathfind
+
+import (
+	"math"
+	"math/rand"
+)
+
+type LandmarkStrategy int
+
+const (
+	// picks landmarks at random.
+	LandmarkRandom LandmarkStrategy = iota
+
+	// picks every next landmark as far away as possible from the ones
+	// picked before it.
+	LandmarkFarthest
+
+	// picks landmarks in the regions where the landmarks picked so far give
+	// the weakest bounds, as described by Goldberg and Harrelson.
+	LandmarkAvoid
+)
+
+type LandmarkOptions struct {
+	Count    int
+	Strategy LandmarkStrategy
+
+	// seeds the random choices of the strategies, for reproducible results.
+	Seed int64
+
+	// set when every edge costs the same in both directions, which allows
+	// for a tighter bound.
+	Symmetric bool
+}
+
+// Landmarks provide the ALT heuristic (A*, landmarks and the triangle
+// inequality) for graphs without a good CostToFinish of their own. The true
+// distances from a few landmarks to every node are computed up front, after
+// which the triangle inequality gives an admissible estimate between any
+// two nodes: d(v, t) >= d(L, t) - d(L, v).
+type Landmarks[T comparable] struct {
+	landmarks []T
+	distances []map[T]int
+	symmetric bool
+}
+
+// picks landmarks among the nodes reachable from origin and computes the
+// distances from each of them. Nodes that can not be reached from origin are
+// not covered by the resulting heuristic.
+func NewLandmarks[T comparable](adapter Adapter[T], origin T, options LandmarkOptions) *Landmarks[T] {
+	l := &Landmarks[T]{symmetric: options.Symmetric}
+	nodes := dijkstra(adapter, origin).order
+	r := rand.New(rand.NewSource(options.Seed))
+
+	for len(l.landmarks) < min(options.Count, len(nodes)) {
+		var landmark T
+
+		switch options.Strategy {
+		case LandmarkFarthest:
+			landmark = l.farthest(nodes)
+
+		case LandmarkAvoid:
+			landmark = l.avoid(adapter, nodes[r.Intn(len(nodes))])
+
+		default:
+			landmark = l.random(nodes, r)
+		}
+
+		l.landmarks = append(l.landmarks, landmark)
+		l.distances = append(l.distances, dijkstra(adapter, landmark).dist)
+	}
+
+	return l
+}
+
+func (l *Landmarks[T]) Landmarks() []T {
+	return l.landmarks
+}
+
+func (l *Landmarks[T]) isLandmark(n T) bool {
+	for _, landmark := range l.landmarks {
+		if landmark == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+// returns a lower bound of the cost of moving from -> to.
+func (l *Landmarks[T]) Estimate(from, to T) int {
+	estimate := 0
+
+	for _, dist := range l.distances {
+		dFrom, reachesFrom := dist[from]
+		dTo, reachesTo := dist[to]
+		if !reachesFrom || !reachesTo {
+			continue
+		}
+
+		estimate = max(estimate, dTo-dFrom)
+		if l.symmetric {
+			estimate = max(estimate, dFrom-dTo)
+		}
+	}
+
+	return estimate
+}
+
+func (l *Landmarks[T]) random(nodes []T, r *rand.Rand) T {
+	for {
+		if n := nodes[r.Intn(len(nodes))]; !l.isLandmark(n) {
+			return n
+		}
+	}
+}
+
+// returns the node furthest away from its closest landmark. Without any
+// landmarks yet, that's the node furthest away from origin.
+func (l *Landmarks[T]) farthest(nodes []T) T {
+	if len(l.landmarks) == 0 {
+		return nodes[len(nodes)-1]
+	}
+
+	// nodes none of the landmarks can reach are covered worst of all.
+	best, bestDistance := nodes[0], -1
+	for _, n := range nodes {
+		closest := math.MaxInt
+		for _, dist := range l.distances {
+			if d, found := dist[n]; found {
+				closest = min(closest, d)
+			}
+		}
+
+		if closest > bestDistance && !l.isLandmark(n) {
+			best, bestDistance = n, closest
+		}
+	}
+
+	return best
+}
+
+// grows a shortest path tree from root and weighs every node by how much the
+// current landmarks underestimate its distance from root. Then descends from
+// root into the heaviest subtree that has no landmark in it yet, returning
+// the leaf it ends up at.
+func (l *Landmarks[T]) avoid(adapter Adapter[T], root T) T {
+	tree := dijkstra(adapter, root)
+
+	children := make(map[T][]T)
+	for _, n := range tree.order[1:] {
+		parent := tree.parent[n]
+		children[parent] = append(children[parent], n)
+	}
+
+	// settle order puts parents before their children, so walking it
+	// backwards sums up subtrees bottom-up.
+	size := make(map[T]int)
+	blocked := make(map[T]bool)
+	for idx := len(tree.order) - 1; idx >= 0; idx-- {
+		n := tree.order[idx]
+		size[n] = tree.dist[n] - l.Estimate(root, n)
+		blocked[n] = l.isLandmark(n)
+
+		for _, child := range children[n] {
+			size[n] += size[child]
+			blocked[n] = blocked[n] || blocked[child]
+		}
+
+		if blocked[n] {
+			size[n] = 0
+		}
+	}
+
+	n := root
+	for {
+		next, nextSize := n, 0
+		for _, child := range children[n] {
+			if size[child] > nextSize {
+				next, nextSize = child, size[child]
+			}
+		}
+
+		if next == n {
+			break
+		}
+		n = next
+	}
+
+	// every subtree holds a landmark already, fall back to picking the
+	// node the current landmarks cover worst.
+	if n == root || l.isLandmark(n) {
+		return l.farthest(tree.order)
+	}
+
+	return n
+}
+
+// returns an adapter that behaves like the given one, except that it
+// estimates the cost to finish using the landmarks.
+func (l *Landmarks[T]) Wrap(adapter Adapter[T], finish T) *LandmarkAdapter[T] {
+	return &LandmarkAdapter[T]{
+		inner:     adapter,
+		landmarks: l,
+		finish:    finish,
+	}
+}
+
+type LandmarkAdapter[T comparable] struct {
+	inner     Adapter[T]
+	landmarks *Landmarks[T]
+	finish    T
+}
+
+func (a *LandmarkAdapter[T]) Neighbours(n T) []T {
+	return a.inner.Neighbours(n)
+}
+
+func (a *LandmarkAdapter[T]) CostToFinish(n T) int {
+	return a.landmarks.Estimate(n, a.finish)
+}
+
+func (a *LandmarkAdapter[T]) IsFinish(n T) bool {
+	return a.inner.IsFinish(n)
+}
+
+func (a *LandmarkAdapter[T]) EdgeCost(from, to T) int {
+	return edgeCost(a.inner, from, to)
+}
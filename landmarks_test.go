@@ -0,0 +1,133 @@
+package pathfind
+
+import (
+	"testing"
+)
+
+// an undirected grid where moving east or west costs more than moving
+// north or south.
+func gridAdapter(width, height int) *FuncAdapter[[2]int] {
+	return &FuncAdapter[[2]int]{
+		NeighboursFn: func(n [2]int) [][2]int {
+			neighbours := [][2]int{}
+			for _, d := range [][2]int{{0, -1}, {-1, 0}, {0, 1}, {1, 0}} {
+				x, y := n[0]+d[0], n[1]+d[1]
+				if x >= 0 && y >= 0 && x < width && y < height {
+					neighbours = append(neighbours, [2]int{x, y})
+				}
+			}
+			return neighbours
+		},
+
+		CostToFinishFn: func([2]int) int { return 0 },
+		IsFinishFn:     func([2]int) bool { return false },
+
+		EdgeCostFn: func(from, to [2]int) int {
+			if from[1] == to[1] {
+				return 3
+			}
+			return 1
+		},
+	}
+}
+
+func TestLandmarksEstimateIsAdmissible(t *testing.T) {
+	adapter := gridAdapter(12, 9)
+	origin := [2]int{0, 0}
+
+	strategies := map[string]LandmarkStrategy{
+		"random":   LandmarkRandom,
+		"farthest": LandmarkFarthest,
+		"avoid":    LandmarkAvoid,
+	}
+
+	for name, strategy := range strategies {
+		strategy := strategy
+		t.Run(name, func(t *testing.T) {
+			l := NewLandmarks[[2]int](adapter, origin, LandmarkOptions{
+				Count:     4,
+				Strategy:  strategy,
+				Seed:      1,
+				Symmetric: true,
+			})
+
+			if len(l.Landmarks()) != 4 {
+				t.Fatalf("expected 4 landmarks, got %v", l.Landmarks())
+			}
+
+			seen := make(map[[2]int]bool)
+			for _, landmark := range l.Landmarks() {
+				if seen[landmark] {
+					t.Errorf("landmark %v picked more than once", landmark)
+				}
+				seen[landmark] = true
+			}
+
+			for _, from := range [][2]int{{0, 0}, {5, 4}, {11, 8}, {3, 7}} {
+				truth := dijkstra[[2]int](adapter, from).dist
+				for to, d := range truth {
+					if estimate := l.Estimate(from, to); estimate > d {
+						t.Errorf("estimate from %v to %v is %d, more than the true cost %d", from, to, estimate, d)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestLandmarksFarthestSpreadsOut(t *testing.T) {
+	l := NewLandmarks[[2]int](gridAdapter(10, 10), [2]int{0, 0}, LandmarkOptions{
+		Count:    2,
+		Strategy: LandmarkFarthest,
+	})
+
+	expected := [][2]int{{9, 9}, {0, 0}}
+	for idx, landmark := range l.Landmarks() {
+		if landmark != expected[idx] {
+			t.Errorf("expected landmarks %v but got %v", expected, l.Landmarks())
+		}
+	}
+}
+
+func TestAStarWithLandmarksFindsCheapestPath(t *testing.T) {
+	grid := gridAdapter(10, 9)
+
+	// a swamp across the middle column, open only at the bottom row, which
+	// makes the shortest path in steps far from the cheapest one.
+	swamp := func(n [2]int) bool { return n[0] == 5 && n[1] < 8 }
+	adapter := &FuncAdapter[[2]int]{
+		NeighboursFn:   grid.NeighboursFn,
+		CostToFinishFn: grid.CostToFinishFn,
+		IsFinishFn:     func(n [2]int) bool { return n == [2]int{9, 0} },
+		EdgeCostFn: func(from, to [2]int) int {
+			if swamp(from) || swamp(to) {
+				return 30
+			}
+			return grid.EdgeCostFn(from, to)
+		},
+	}
+
+	start, finish := [2]int{0, 0}, [2]int{9, 0}
+	l := NewLandmarks[[2]int](adapter, start, LandmarkOptions{
+		Count:     4,
+		Strategy:  LandmarkAvoid,
+		Seed:      1,
+		Symmetric: true,
+	})
+
+	solver := NewSolver[[2]int](AlgorithmAStar, start, l.Wrap(adapter, finish))
+	path := solver.Walk()
+	if len(path) == 0 || path[0] != finish || path[len(path)-1] != start {
+		t.Fatalf("expected a path from %v to %v, got %v", start, finish, path)
+	}
+
+	cost := 0
+	for idx := 1; idx < len(path); idx++ {
+		cost += adapter.EdgeCost(path[idx], path[idx-1])
+	}
+
+	expected := dijkstra[[2]int](adapter, start).dist[finish]
+	if cost != expected {
+		t.Errorf("expected the cheapest path to cost %d, got %d: %v", expected, cost, path)
+	}
+}
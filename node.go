@@ -0,0 +1,47 @@
+package pathfind
+
+// parent of the node a search starts from.
+const noParent = -1
+
+// number of nodes per page of a nodeSlab, a power of two.
+const slabPageSize = 1 << 10
+
+// a node of the search tree, referring to its parent by index.
+type node[T comparable] struct {
+	coord  T
+	parent int
+	cost   int
+}
+
+// holds the nodes of a search in fixed size pages. Referring to nodes by
+// index instead of by pointer means adding a node rarely allocates, and the
+// garbage collector has no chains of parents to walk. Unlike one growing
+// slice, pages never have to be copied over when the slab fills up.
+type nodeSlab[T comparable] struct {
+	pages [][]node[T]
+	len   int
+}
+
+// stores a node and returns its index.
+func (s *nodeSlab[T]) add(coord T, parent, cost int) int {
+	if s.len%slabPageSize == 0 {
+		s.pages = append(s.pages, make([]node[T], 0, slabPageSize))
+	}
+
+	page := len(s.pages) - 1
+	s.pages[page] = append(s.pages[page], node[T]{coord: coord, parent: parent, cost: cost})
+	s.len++
+
+	return s.len - 1
+}
+
+func (s *nodeSlab[T]) at(idx int) node[T] {
+	return s.pages[idx/slabPageSize][idx%slabPageSize]
+}
+
+// points a node that is reached along a cheaper route at its new parent.
+func (s *nodeSlab[T]) reparent(idx, parent, cost int) {
+	n := &s.pages[idx/slabPageSize][idx%slabPageSize]
+	n.parent = parent
+	n.cost = cost
+}
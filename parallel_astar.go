@@ -0,0 +1,298 @@
+package pathfind
+
+import (
+	"math"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/tmw/pathfind/pkg/frontier"
+)
+
+// adapters need to implement Hasher, or Indexer, for AlgorithmParallelAStar
+// to spread candidates over its workers. There is no way to hash any T in
+// go 1.21, so without either a single worker ends up owning all candidates,
+// making the search sequential.
+type Hasher[T comparable] interface {
+	// to return a hash of the given T. Equal values must hash the same.
+	Hash(T) uint64
+}
+
+// hash distributed A* (HDA*). Every candidate is owned by exactly one worker,
+// picked by its hash, which keeps the open list and best known costs for the
+// candidates it owns. Workers send the neighbours they find to their owners,
+// so no locking is needed beyond the inboxes. As workers don't expand in
+// global order, finding the finish doesn't end the search; the cost found so
+// far merely prunes the candidates that can't beat it, until none are left.
+type parallelAStar[T comparable] struct {
+	start T
+}
+
+func newParallelAStar[T comparable](start T) *parallelAStar[T] {
+	return &parallelAStar[T]{start: start}
+}
+
+type hdaWorker[T comparable] struct {
+	search *hdaSearch[T]
+	id     int
+
+	// the nodes owned by this worker. Their parents may be owned by other
+	// workers, so parents are referred to by ref rather than plain index.
+	nodes   nodeSlab[T]
+	open    frontier.Frontier[T, int, int]
+	reached map[T]int
+	nearest closest
+
+	mu    sync.Mutex
+	inbox []node[T]
+	wake  chan struct{}
+}
+
+type hdaSearch[T comparable] struct {
+	ctx     SolveContext[T]
+	workers []*hdaWorker[T]
+	hash    func(T) uint64
+
+	// number of candidates sent but not fully processed yet. Children are
+	// sent before their parent is marked as processed, so this only drops to
+	// zero once the search space is exhausted.
+	pending atomic.Int64
+	done    chan struct{}
+	once    sync.Once
+
+	// cost of the best path found so far, and the ref of its final node.
+	incumbent atomic.Int64
+	mu        sync.Mutex
+	best      int
+
+	maxCostReached atomic.Bool
+}
+
+func (w *parallelAStar[T]) Walk(ctx SolveContext[T]) []T {
+	workers := ctx.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	s := &hdaSearch[T]{
+		ctx:  synchronised(ctx),
+		hash: hasherFor(ctx.Adapter()),
+		done: make(chan struct{}),
+		best: noParent,
+	}
+	s.incumbent.Store(math.MaxInt64)
+
+	for i := 0; i < workers; i++ {
+		s.workers = append(s.workers, &hdaWorker[T]{
+			search:  s,
+			id:      i,
+			open:    newFrontier[T](ctx.Frontier, FrontierBinaryHeap),
+			reached: make(map[T]int),
+			nearest: newClosest(),
+			wake:    make(chan struct{}, 1),
+		})
+	}
+
+	var wg sync.WaitGroup
+	for _, worker := range s.workers {
+		wg.Add(1)
+		go func(worker *hdaWorker[T]) {
+			defer wg.Done()
+			worker.run()
+		}(worker)
+	}
+
+	s.send(node[T]{coord: w.start, parent: noParent})
+	wg.Wait()
+
+	if s.best != noParent {
+		path := backtrace[T](s.at, s.best)
+		ctx.Publish(EventFinishReached[T]{Path: path, Actions: actionsAlong(ctx.Adapter(), path)})
+		return path
+	}
+
+	if s.maxCostReached.Load() {
+		ctx.Publish(EventMaxCostReached{})
+	}
+
+	ctx.Publish(EventUnsolvable{})
+	return partialPath(ctx, s.at, s.nearest())
+}
+
+func hasherFor[T comparable](adapter Adapter[T]) func(T) uint64 {
+	if hasher, ok := adapter.(Hasher[T]); ok {
+		return hasher.Hash
+	}
+
+	if indexer, ok := optional[Indexer[T]](adapter); ok {
+		return func(n T) uint64 { return uint64(indexer.Index(n)) }
+	}
+
+	return func(T) uint64 { return 0 }
+}
+
+// returns a copy of ctx that is safe to use from multiple workers. The event
+// log and visited set of the solver aren't, and sequential walkers shouldn't
+// pay for locking them.
+func synchronised[T comparable](ctx SolveContext[T]) SolveContext[T] {
+	var mu sync.Mutex
+	publish, isVisited, visit := ctx.Publish, ctx.IsVisited, ctx.Visit
+
+	ctx.Publish = func(e Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		publish(e)
+	}
+
+	ctx.IsVisited = func(n T) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return isVisited(n)
+	}
+
+	ctx.Visit = func(n T) {
+		mu.Lock()
+		defer mu.Unlock()
+		visit(n)
+	}
+
+	return ctx
+}
+
+// refers to node idx of worker id, unique across all workers.
+func (s *hdaSearch[T]) ref(id, idx int) int {
+	return idx*len(s.workers) + id
+}
+
+// looks up a node by ref. Only safe once all workers have stopped.
+func (s *hdaSearch[T]) at(ref int) node[T] {
+	return s.workers[ref%len(s.workers)].nodes.at(ref / len(s.workers))
+}
+
+// hands the node to the worker owning it, which stores it in its own slab.
+func (s *hdaSearch[T]) send(c node[T]) {
+	s.pending.Add(1)
+
+	owner := s.workers[s.hash(c.coord)%uint64(len(s.workers))]
+	owner.mu.Lock()
+	owner.inbox = append(owner.inbox, c)
+	owner.mu.Unlock()
+
+	select {
+	case owner.wake <- struct{}{}:
+	default:
+	}
+}
+
+// returns the closest node found by any of the workers, by ref. Only safe
+// once all workers have stopped.
+func (s *hdaSearch[T]) nearest() closest {
+	nearest := newClosest()
+	for _, w := range s.workers {
+		if w.nearest.idx != noParent {
+			nearest.offer(s.ref(w.id, w.nearest.idx), w.nearest.estimate)
+		}
+	}
+	return nearest
+}
+
+func (s *hdaSearch[T]) processed() {
+	if s.pending.Add(-1) == 0 {
+		s.once.Do(func() { close(s.done) })
+	}
+}
+
+func (s *hdaSearch[T]) finishReached(ref, cost int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if int64(cost) < s.incumbent.Load() {
+		s.incumbent.Store(int64(cost))
+		s.best = ref
+	}
+}
+
+func (w *hdaWorker[T]) run() {
+	for {
+		w.receive()
+
+		if w.open.Len() == 0 {
+			select {
+			case <-w.wake:
+				continue
+			case <-w.search.done:
+				return
+			}
+		}
+
+		_, idx := w.open.Pop()
+		w.expand(idx)
+		w.search.processed()
+	}
+}
+
+// moves the inbox over into the open list, dropping candidates that are no
+// cheaper than what this worker has seen before.
+func (w *hdaWorker[T]) receive() {
+	w.mu.Lock()
+	inbox := w.inbox
+	w.inbox = nil
+	w.mu.Unlock()
+
+	ctx := w.search.ctx
+	for _, c := range inbox {
+		idx, found := w.reached[c.coord]
+		if found && w.nodes.at(idx).cost <= c.cost {
+			w.search.processed()
+			continue
+		}
+
+		// the node replaces a more expensive one still queued, which will
+		// never be expanded now.
+		if w.open.Contains(c.coord) {
+			w.search.processed()
+		}
+
+		if found {
+			w.nodes.reparent(idx, c.parent, c.cost)
+		} else {
+			idx = w.nodes.add(c.coord, c.parent, c.cost)
+			w.reached[c.coord] = idx
+		}
+
+		w.open.Push(c.coord, idx, c.cost+ctx.Adapter().CostToFinish(c.coord))
+		ctx.Publish(EventCandidateAdded[T]{CandidateID: c.coord})
+	}
+}
+
+func (w *hdaWorker[T]) expand(idx int) {
+	ctx := w.search.ctx
+	c := w.nodes.at(idx)
+	ref := w.search.ref(w.id, idx)
+
+	if int64(c.cost+ctx.Adapter().CostToFinish(c.coord)) >= w.search.incumbent.Load() {
+		return
+	}
+
+	if ctx.MaxCost > 0 && c.cost >= ctx.MaxCost {
+		w.search.maxCostReached.Store(true)
+		return
+	}
+
+	if ctx.Adapter().IsFinish(c.coord) {
+		w.search.finishReached(ref, c.cost)
+		return
+	}
+
+	ctx.Visit(c.coord)
+	ctx.Publish(EventCandidateVisited[T]{CandidateID: c.coord})
+	w.nearest.offer(idx, ctx.Adapter().CostToFinish(c.coord))
+
+	for _, n := range ctx.Adapter().Neighbours(c.coord) {
+		w.search.send(node[T]{
+			coord:  n,
+			parent: ref,
+			cost:   c.cost + edgeCost(ctx.Adapter(), c.coord, n),
+		})
+	}
+}
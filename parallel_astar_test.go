@@ -0,0 +1,134 @@
+package pathfind
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// a weighted grid with random walls, where the finish sits in the bottom
+// right corner.
+func randomMaze(size int, seed int64) *FuncAdapter[[2]int] {
+	r := rand.New(rand.NewSource(seed))
+	walls := make(map[[2]int]bool)
+	weights := make(map[[2]int]int)
+	for x := 0; x < size; x++ {
+		for y := 0; y < size; y++ {
+			walls[[2]int{x, y}] = r.Intn(4) == 0
+			weights[[2]int{x, y}] = 1 + r.Intn(5)
+		}
+	}
+
+	finish := [2]int{size - 1, size - 1}
+	walls[[2]int{0, 0}], walls[finish] = false, false
+
+	return &FuncAdapter[[2]int]{
+		NeighboursFn: func(n [2]int) [][2]int {
+			neighbours := [][2]int{}
+			for _, d := range [][2]int{{0, -1}, {-1, 0}, {0, 1}, {1, 0}} {
+				next := [2]int{n[0] + d[0], n[1] + d[1]}
+				if next[0] >= 0 && next[1] >= 0 && next[0] < size && next[1] < size && !walls[next] {
+					neighbours = append(neighbours, next)
+				}
+			}
+			return neighbours
+		},
+
+		CostToFinishFn: func(n [2]int) int {
+			return finish[0] - n[0] + finish[1] - n[1]
+		},
+
+		IsFinishFn: func(n [2]int) bool {
+			return n == finish
+		},
+
+		EdgeCostFn: func(_, to [2]int) int {
+			return weights[to]
+		},
+	}
+}
+
+// spreads the maze over the workers, which takes a Hasher.
+type hashedMaze struct {
+	*FuncAdapter[[2]int]
+}
+
+func (hashedMaze) Hash(n [2]int) uint64 {
+	return uint64(n[0]*31 + n[1])
+}
+
+func TestHasherFor(t *testing.T) {
+	maze := randomMaze(4, 1)
+	n := [2]int{2, 3}
+
+	if hash := hasherFor[[2]int](hashedMaze{maze})(n); hash != 65 {
+		t.Errorf("expected the Hash of the adapter to be used, got %d", hash)
+	}
+
+	indexed := struct {
+		*FuncAdapter[[2]int]
+		gridIndexer
+	}{maze, gridIndexer{}}
+	if hash := hasherFor[[2]int](indexed)(n); hash != 302 {
+		t.Errorf("expected the Index of the adapter to be used, got %d", hash)
+	}
+
+	if hash := hasherFor[[2]int](maze)(n); hash != 0 {
+		t.Errorf("expected a single owner without Hasher or Indexer, got %d", hash)
+	}
+}
+
+func TestParallelAStarIsOptimal(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		adapter := randomMaze(24, seed)
+		start := [2]int{0, 0}
+
+		expected, reachable := dijkstra[[2]int](adapter, start).dist[[2]int{23, 23}]
+
+		s := NewSolver[[2]int](AlgorithmParallelAStar, start, hashedMaze{adapter})
+		s.Workers = 4
+		path := s.Walk()
+
+		if !reachable {
+			if len(path) != 0 {
+				t.Errorf("seed %d: expected no path but got %v", seed, path)
+			}
+			continue
+		}
+
+		if len(path) == 0 {
+			t.Fatalf("seed %d: expected a path but found none", seed)
+		}
+
+		if actual := pathCost[[2]int](adapter, path); actual != expected {
+			t.Errorf("seed %d: expected path cost %d but got %d", seed, expected, actual)
+		}
+
+		if path[len(path)-1] != start {
+			t.Errorf("seed %d: expected path to end at start, got %v", seed, path[len(path)-1])
+		}
+
+		events := s.EventLog()
+		if _, ok := events[len(events)-1].(EventFinishReached[[2]int]); !ok {
+			t.Errorf("seed %d: expected last event to be EventFinishReached, got %T", seed, events[len(events)-1])
+		}
+	}
+}
+
+func TestParallelAStarMaxCost(t *testing.T) {
+	s := NewSolver[[2]int](AlgorithmParallelAStar, [2]int{0, 0}, randomMaze(24, 3))
+	s.MaxCost = 5
+
+	if path := s.Walk(); len(path) != 0 {
+		t.Errorf("expected no path within max cost, got %v", path)
+	}
+
+	found := false
+	for _, e := range s.EventLog() {
+		_, ok := e.(EventMaxCostReached)
+		found = found || ok
+	}
+
+	if !found {
+		t.Error("expected EventMaxCostReached to be published")
+	}
+}
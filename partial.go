@@ -0,0 +1,33 @@
+package pathfind
+
+// keeps track of the expanded node estimated closest to the finish, for
+// returning a partial path when the finish can't be reached.
+type closest struct {
+	idx      int
+	estimate int
+}
+
+func newClosest() closest {
+	return closest{idx: noParent}
+}
+
+// records the node at idx if it is estimated closer to the finish than the
+// closest node so far. On ties, the node expanded first wins.
+func (c *closest) offer(idx, estimate int) {
+	if c.idx == noParent || estimate < c.estimate {
+		c.idx = idx
+		c.estimate = estimate
+	}
+}
+
+// returns the path to the closest node when AllowPartial is set, and an
+// empty path otherwise. Meant for walkers giving up on reaching the finish.
+func partialPath[T comparable](ctx SolveContext[T], at func(int) node[T], c closest) []T {
+	if !ctx.AllowPartial || c.idx == noParent {
+		return []T{}
+	}
+
+	path := backtrace[T](at, c.idx)
+	ctx.Publish(EventPartialPath[T]{Path: path, Actions: actionsAlong(ctx.Adapter(), path)})
+	return path
+}
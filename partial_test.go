@@ -0,0 +1,93 @@
+package pathfind
+
+import (
+	"testing"
+
+	"github.com/tmw/pathfind/pkg/arena"
+)
+
+// the finish is walled off, the cell right above its wall is closest.
+const walledOff = `
+S......
+.......
+.#####.
+.##F##.
+.#####.`
+
+func TestAllowPartial(t *testing.T) {
+	a, err := arena.Parse(walledOff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	closest := arena.NewCoordinate(3, 1)
+	algorithms := map[string]Algorithm{
+		"bfs":            AlgorithmBFS,
+		"astar":          AlgorithmAStar,
+		"parallel astar": AlgorithmParallelAStar,
+	}
+
+	for name, algorithm := range algorithms {
+		algorithm := algorithm
+		t.Run(name, func(t *testing.T) {
+			s := NewSolver[arena.Coordinate](algorithm, a.StartCoordinate(), arena.NewAdapter(a, a.FinishCoordinate()))
+			if path := s.Walk(); len(path) != 0 {
+				t.Errorf("expected no path without AllowPartial, got %v", path)
+			}
+
+			s = NewSolver[arena.Coordinate](algorithm, a.StartCoordinate(), arena.NewAdapter(a, a.FinishCoordinate()))
+			s.AllowPartial = true
+			path := s.Walk()
+
+			if len(path) != 5 || path[0] != closest || path[len(path)-1] != a.StartCoordinate() {
+				t.Errorf("expected a path of 5 cells from %v to the start, got %v", closest, path)
+			}
+
+			if s.Status() != StatusUnsolvable {
+				t.Errorf("expected status %s, got %s", StatusUnsolvable, s.Status())
+			}
+
+			events := s.EventLog()
+			if partial, ok := events[len(events)-1].(EventPartialPath[arena.Coordinate]); !ok || len(partial.Path) != len(path) {
+				t.Errorf("expected EventPartialPath as last event, got %T", events[len(events)-1])
+			}
+		})
+	}
+}
+
+func TestAllowPartialWithMaxCost(t *testing.T) {
+	a, err := arena.Parse("S.........F")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewSolver[arena.Coordinate](AlgorithmAStar, a.StartCoordinate(), arena.NewAdapter(a, a.FinishCoordinate()))
+	s.MaxCost = 4
+	s.AllowPartial = true
+	path := s.Walk()
+
+	if len(path) != 4 || path[0] != arena.NewCoordinate(3, 0) {
+		t.Errorf("expected a path of 4 cells ending 3 cells in, got %v", path)
+	}
+
+	if s.Status() != StatusMaxCostReached {
+		t.Errorf("expected status %s, got %s", StatusMaxCostReached, s.Status())
+	}
+}
+
+func TestStatus(t *testing.T) {
+	a, err := arena.Parse("S..\n.#.\n..F")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewSolver[arena.Coordinate](AlgorithmAStar, a.StartCoordinate(), arena.NewAdapter(a, a.FinishCoordinate()))
+	if s.Status() != StatusPending {
+		t.Errorf("expected status %s before walking, got %s", StatusPending, s.Status())
+	}
+
+	s.Walk()
+	if s.Status() != StatusFinishReached {
+		t.Errorf("expected status %s, got %s", StatusFinishReached, s.Status())
+	}
+}
@@ -0,0 +1,52 @@
+package arena
+
+// Adapter moves between the walkable cells of an arena, towards the given
+// finish. Every step costs 1, which makes its estimate (the manhattan
+// distance to the finish) admissible.
+type Adapter struct {
+	arena  *Arena
+	finish Coordinate
+}
+
+func NewAdapter(a *Arena, finish Coordinate) *Adapter {
+	return &Adapter{
+		arena:  a,
+		finish: finish,
+	}
+}
+
+// filters the cells around c in place, as every call gets a slice of its own.
+func (a *Adapter) Neighbours(c Coordinate) []Coordinate {
+	neighbours := a.arena.NeighboursOfCoordinate(c)
+	walkable := neighbours[:0]
+	for _, n := range neighbours {
+		if a.arena.IsWalkable(n) {
+			walkable = append(walkable, n)
+		}
+	}
+	return walkable
+}
+
+// moves are symmetric, so the cells leading to c are its neighbours.
+func (a *Adapter) Predecessors(c Coordinate) []Coordinate {
+	return a.Neighbours(c)
+}
+
+// lets solvers keep track of visited cells in a bitset.
+func (a *Adapter) Index(c Coordinate) int {
+	return a.arena.Index(c)
+}
+
+func (a *Adapter) CostToFinish(c Coordinate) int {
+	return c.DistanceTo(a.finish)
+}
+
+// estimates the cost between any two cells, for searches towards other
+// finishes than the adapter's own.
+func (a *Adapter) Estimate(from, to Coordinate) int {
+	return from.DistanceTo(to)
+}
+
+func (a *Adapter) IsFinish(c Coordinate) bool {
+	return c == a.finish
+}
@@ -8,19 +8,22 @@ import (
 
 type Arena struct {
 	cells      [][]CellType
+	labels     map[Coordinate]string
 	startCell  Coordinate
 	finishCell Coordinate
+	waypoints  []Coordinate
+	width      int
 }
 
 // render the map into the writer
 func (m *Arena) Render(w io.Writer) {
-	for x := range m.cells {
-		if x > 0 {
+	for y := range m.cells {
+		if y > 0 {
 			fmt.Fprintf(w, "\n")
 		}
 
-		for y := range m.cells[x] {
-			fmt.Fprintf(w, "%s", m.cells[x][y])
+		for x := range m.cells[y] {
+			fmt.Fprintf(w, "%s", m.symbolAt(Coordinate{x: x, y: y}))
 		}
 	}
 }
@@ -36,7 +39,7 @@ func (m *Arena) RenderWithVisited(w io.Writer, visited []Coordinate) {
 			if slices.Contains(visited, c) {
 				fmt.Fprint(w, "v")
 			} else {
-				fmt.Fprintf(w, "%s", m.cells[y][x])
+				fmt.Fprintf(w, "%s", m.symbolAt(c))
 			}
 		}
 	}
@@ -53,14 +56,23 @@ func (m *Arena) RenderWithPath(w io.Writer, path []Coordinate) {
 			if slices.Contains(path, c) {
 				fmt.Fprintf(w, "%s", SymbolPath)
 			} else {
-				fmt.Fprintf(w, "%s", m.cells[y][x])
+				fmt.Fprintf(w, "%s", m.symbolAt(c))
 			}
 		}
 	}
 }
 
+// returns the symbol to render for the cell at the given coordinate.
+func (m *Arena) symbolAt(c Coordinate) string {
+	if label, found := m.labels[c]; found {
+		return label
+	}
+
+	return m.cells[c.y][c.x].String()
+}
+
 func (m *Arena) NeighboursOfCoordinate(c Coordinate) []Coordinate {
-	neighbours := []Coordinate{}
+	neighbours := make([]Coordinate, 0, 4)
 
 	if n := c.North(); m.CellTypeForCoordinate(n) != CellTypeUndefined {
 		neighbours = append(neighbours, n)
@@ -98,6 +110,27 @@ func (m *Arena) CellTypeForCoordinate(c Coordinate) CellType {
 	return m.cells[c.y][c.x]
 }
 
+func (m *Arena) Width() int {
+	return m.width
+}
+
+func (m *Arena) Height() int {
+	return len(m.cells)
+}
+
+// numbers the cells of the arena row by row, so coordinates within the
+// arena map onto 0 up to Width() * Height().
+func (m *Arena) Index(c Coordinate) int {
+	return c.y*m.width + c.x
+}
+
+// returns true if the cell at the given coordinate exists and can be walked on.
+// Doors are not considered walkable, as that depends on the keys collected.
+func (m *Arena) IsWalkable(c Coordinate) bool {
+	t := m.CellTypeForCoordinate(c)
+	return t != CellTypeUndefined && t != CellTypeNonWalkable && t != CellTypeDoor
+}
+
 func (m *Arena) StartCoordinate() Coordinate {
 	return m.startCell
 }
@@ -105,3 +138,8 @@ func (m *Arena) StartCoordinate() Coordinate {
 func (m *Arena) FinishCoordinate() Coordinate {
 	return m.finishCell
 }
+
+// returns the waypoint coordinates in the order they are numbered in.
+func (m *Arena) Waypoints() []Coordinate {
+	return slices.Clone(m.waypoints)
+}
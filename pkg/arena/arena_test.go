@@ -162,3 +162,21 @@ func TestFinishCoordinate(t *testing.T) {
 		t.Errorf("expected FinishCoordinate() to return %+v but received %+v", expected, actual)
 	}
 }
+
+func TestIndex(t *testing.T) {
+	a, err := Parse("S...\n.#..\n...F")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seen := make(map[int]bool)
+	for y := 0; y < a.Height(); y++ {
+		for x := 0; x < a.Width(); x++ {
+			idx := a.Index(NewCoordinate(x, y))
+			if idx < 0 || idx >= a.Width()*a.Height() || seen[idx] {
+				t.Errorf("unexpected index %d for %d,%d", idx, x, y)
+			}
+			seen[idx] = true
+		}
+	}
+}
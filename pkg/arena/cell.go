@@ -1,5 +1,7 @@
 package arena
 
+import "strings"
+
 type CellType uint8
 
 const (
@@ -9,6 +11,9 @@ const (
 	CellTypeStart
 	CellTypeFinish
 	CellTypePath
+	CellTypeWaypoint
+	CellTypeKey
+	CellTypeDoor
 )
 
 func (t CellType) String() string {
@@ -43,8 +48,40 @@ func symbolToType(i string) CellType {
 
 	case SymbolWalkable:
 		return CellTypeWalkable
+	}
 
-	default:
-		return CellTypeWalkable
+	if isWaypointSymbol(i) {
+		return CellTypeWaypoint
 	}
+
+	if isKeySymbol(i) {
+		return CellTypeKey
+	}
+
+	if isDoorSymbol(i) {
+		return CellTypeDoor
+	}
+
+	return CellTypeWalkable
+}
+
+// waypoints are numbered using a single digit, visited in ascending order.
+func isWaypointSymbol(i string) bool {
+	return len(i) == 1 && i[0] >= '0' && i[0] <= '9'
+}
+
+// keys are the lowercase letters listed in SymbolKeys, opening the door of
+// the same uppercase letter.
+func isKeySymbol(i string) bool {
+	return len(i) == 1 && i[0] >= 'a' && i[0] <= 'z' && strings.Contains(SymbolKeys, i)
+}
+
+func isDoorSymbol(i string) bool {
+	return len(i) == 1 && i[0] >= 'A' && i[0] <= 'Z' && strings.Contains(SymbolKeys, strings.ToLower(i))
+}
+
+// returns true for cell types that render as the symbol they were parsed
+// from, rather than a symbol shared by all cells of that type.
+func (t CellType) isLabelled() bool {
+	return t == CellTypeWaypoint || t == CellTypeKey || t == CellTypeDoor
 }
@@ -0,0 +1,141 @@
+package arena
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/tmw/pathfind/pkg/queue"
+)
+
+type Connectivity int
+
+const (
+	// cells connect to their north, east, south and west neighbours, the
+	// same way the arena adapters move.
+	FourConnected Connectivity = iota
+
+	// cells connect to their diagonal neighbours as well.
+	EightConnected
+)
+
+// symbols used by RenderRegions, repeating when there are more regions. S
+// and F are left out to not be mistaken for start and finish.
+const regionSymbols = "ABCDEGHIJKLMNOPQRTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+// a group of walkable cells that can all be reached from one another.
+type Region struct {
+	ID   int
+	Size int
+
+	// corners of the bounding box around the region, inclusive.
+	Min Coordinate
+	Max Coordinate
+}
+
+// the regions of an arena, see Arena.Components.
+type Components struct {
+	// region ID for every cell, -1 for cells that can't be walked on.
+	labels  [][]int
+	regions []Region
+}
+
+// labels the walkable cells of the arena by the region they belong to, such
+// that two cells share a region when one can be reached from the other.
+// Regions are numbered in the order they're found, from the top left.
+func (m *Arena) Components(connectivity Connectivity) *Components {
+	offsets := [][2]int{{0, -1}, {-1, 0}, {0, 1}, {1, 0}}
+	if connectivity == EightConnected {
+		offsets = append(offsets, [2]int{-1, -1}, [2]int{1, -1}, [2]int{-1, 1}, [2]int{1, 1})
+	}
+
+	c := &Components{
+		labels:  make([][]int, len(m.cells)),
+		regions: []Region{},
+	}
+
+	for y := range m.cells {
+		c.labels[y] = make([]int, len(m.cells[y]))
+		for x := range c.labels[y] {
+			c.labels[y][x] = -1
+		}
+	}
+
+	for y := range m.cells {
+		for x := range m.cells[y] {
+			if start := (Coordinate{x: x, y: y}); c.labels[y][x] == -1 && m.IsWalkable(start) {
+				c.regions = append(c.regions, m.fill(c, start, len(c.regions), offsets))
+			}
+		}
+	}
+
+	return c
+}
+
+// flood fills the region around start, labelling its cells with id.
+func (m *Arena) fill(c *Components, start Coordinate, id int, offsets [][2]int) Region {
+	region := Region{ID: id, Min: start, Max: start}
+	c.labels[start.y][start.x] = id
+
+	candidates := queue.New(start)
+	for !candidates.Empty() {
+		current := candidates.Pop()
+
+		region.Size++
+		region.Min = Coordinate{x: min(region.Min.x, current.x), y: min(region.Min.y, current.y)}
+		region.Max = Coordinate{x: max(region.Max.x, current.x), y: max(region.Max.y, current.y)}
+
+		for _, offset := range offsets {
+			n := Coordinate{x: current.x + offset[0], y: current.y + offset[1]}
+			if m.IsWalkable(n) && c.labels[n.y][n.x] == -1 {
+				c.labels[n.y][n.x] = id
+				candidates.Push(n)
+			}
+		}
+	}
+
+	return region
+}
+
+// returns the ID of the region the coordinate belongs to, if any.
+func (c *Components) RegionOf(co Coordinate) (int, bool) {
+	if co.y < 0 || co.y >= len(c.labels) || co.x < 0 || co.x >= len(c.labels[co.y]) {
+		return -1, false
+	}
+
+	id := c.labels[co.y][co.x]
+	return id, id != -1
+}
+
+func (c *Components) Regions() []Region {
+	return c.regions
+}
+
+// returns true if b can be reached from a.
+func (c *Components) Reachable(a, b Coordinate) bool {
+	regionA, found := c.RegionOf(a)
+	if !found {
+		return false
+	}
+
+	regionB, found := c.RegionOf(b)
+	return found && regionA == regionB
+}
+
+// renders the arena with every walkable cell replaced by the symbol of its
+// region.
+func (m *Arena) RenderRegions(w io.Writer, c *Components) {
+	for y := range m.cells {
+		if y > 0 {
+			fmt.Fprintf(w, "\n")
+		}
+
+		for x := range m.cells[y] {
+			co := Coordinate{x: x, y: y}
+			if id, found := c.RegionOf(co); found {
+				fmt.Fprintf(w, "%c", regionSymbols[id%len(regionSymbols)])
+			} else {
+				fmt.Fprintf(w, "%s", m.symbolAt(co))
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package arena
+
+import (
+	"strings"
+	"testing"
+)
+
+const regionsInput = `
+##########
+#S.#.....#
+#..#.##..#
+####.#.###
+#...#.#.F#
+##########
+`
+
+func TestComponents(t *testing.T) {
+	a, err := Parse(regionsInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		connectivity Connectivity
+		regions      []Region
+	}{
+		"four connected": {
+			connectivity: FourConnected,
+			regions: []Region{
+				{ID: 0, Size: 4, Min: NewCoordinate(1, 1), Max: NewCoordinate(2, 2)},
+				{ID: 1, Size: 9, Min: NewCoordinate(4, 1), Max: NewCoordinate(8, 3)},
+				{ID: 2, Size: 1, Min: NewCoordinate(6, 3), Max: NewCoordinate(6, 3)},
+				{ID: 3, Size: 3, Min: NewCoordinate(1, 4), Max: NewCoordinate(3, 4)},
+				{ID: 4, Size: 1, Min: NewCoordinate(5, 4), Max: NewCoordinate(5, 4)},
+				{ID: 5, Size: 2, Min: NewCoordinate(7, 4), Max: NewCoordinate(8, 4)},
+			},
+		},
+		"eight connected": {
+			connectivity: EightConnected,
+			regions: []Region{
+				{ID: 0, Size: 4, Min: NewCoordinate(1, 1), Max: NewCoordinate(2, 2)},
+				{ID: 1, Size: 16, Min: NewCoordinate(1, 1), Max: NewCoordinate(8, 4)},
+			},
+		},
+	}
+
+	for name, td := range tests {
+		td := td
+		t.Run(name, func(t *testing.T) {
+			actual := a.Components(td.connectivity).Regions()
+			if len(actual) != len(td.regions) {
+				t.Fatalf("expected %d regions but got %d: %+v", len(td.regions), len(actual), actual)
+			}
+
+			for idx := range actual {
+				if actual[idx] != td.regions[idx] {
+					t.Errorf("expected region %+v but got %+v", td.regions[idx], actual[idx])
+				}
+			}
+		})
+	}
+}
+
+func TestReachable(t *testing.T) {
+	a, err := Parse(regionsInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := a.Components(FourConnected)
+
+	if c.Reachable(a.StartCoordinate(), a.FinishCoordinate()) {
+		t.Error("expected finish to be unreachable from start")
+	}
+
+	if !c.Reachable(NewCoordinate(4, 1), NewCoordinate(8, 2)) {
+		t.Error("expected cells of the same region to be reachable")
+	}
+
+	if c.Reachable(NewCoordinate(0, 0), NewCoordinate(0, 0)) {
+		t.Error("expected walls to never be reachable")
+	}
+
+	if c.Reachable(NewCoordinate(-1, 3), NewCoordinate(1, 1)) {
+		t.Error("expected cells outside of the arena to never be reachable")
+	}
+}
+
+func TestRenderRegions(t *testing.T) {
+	a, err := Parse(regionsInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	a.RenderRegions(&out, a.Components(FourConnected))
+
+	expected := "" +
+		"##########\n" +
+		"#AA#BBBBB#\n" +
+		"#AA#B##BB#\n" +
+		"####B#C###\n" +
+		"#DDD#E#GG#\n" +
+		"##########"
+
+	compare(t, out.String(), expected)
+}
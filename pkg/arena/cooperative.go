@@ -0,0 +1,36 @@
+package arena
+
+// CooperativeAdapter lets a single agent search the arena through space and
+// time, avoiding the cells and moves reserved by agents planned before it.
+// Its candidates are TimedCoordinates, so every move (including waiting in
+// place) costs one tick. Use it with AlgorithmAStar and a MaxCost, as the
+// search space is unbounded in time.
+type CooperativeAdapter struct {
+	arena  *Arena
+	table  *ReservationTable
+	finish Coordinate
+}
+
+func NewCooperativeAdapter(a *Arena, table *ReservationTable, finish Coordinate) *CooperativeAdapter {
+	return &CooperativeAdapter{
+		arena:  a,
+		table:  table,
+		finish: finish,
+	}
+}
+
+func (c *CooperativeAdapter) Neighbours(t TimedCoordinate) []TimedCoordinate {
+	return c.arena.timedNeighbours(t, func(from, to TimedCoordinate) bool {
+		return !c.table.IsReserved(to) && !c.table.IsSwap(from, to)
+	})
+}
+
+func (c *CooperativeAdapter) CostToFinish(t TimedCoordinate) int {
+	return t.DistanceTo(c.finish)
+}
+
+// the finish only counts as reached once the agent can stay there without
+// getting in the way of agents passing through later on.
+func (c *CooperativeAdapter) IsFinish(t TimedCoordinate) bool {
+	return t.Coordinate == c.finish && !c.table.isReservedAfter(t)
+}
@@ -0,0 +1,93 @@
+package arena
+
+import (
+	"slices"
+	"testing"
+)
+
+const corridor = `
+#######
+#S...F#
+#######
+`
+
+func TestCooperativeAdapterNeighbours(t *testing.T) {
+	a, err := Parse(corridor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table := NewReservationTable()
+	table.Reserve([]TimedCoordinate{
+		NewTimedCoordinate(NewCoordinate(3, 1), 0),
+		NewTimedCoordinate(NewCoordinate(2, 1), 1),
+		NewTimedCoordinate(NewCoordinate(1, 1), 2),
+	})
+
+	adapter := NewCooperativeAdapter(a, table, NewCoordinate(5, 1))
+
+	tests := map[string]struct {
+		from TimedCoordinate
+		e    []TimedCoordinate
+	}{
+		"waiting and moving are both allowed": {
+			from: NewTimedCoordinate(NewCoordinate(4, 1), 0),
+			e: []TimedCoordinate{
+				NewTimedCoordinate(NewCoordinate(3, 1), 1),
+				NewTimedCoordinate(NewCoordinate(5, 1), 1),
+				NewTimedCoordinate(NewCoordinate(4, 1), 1),
+			},
+		},
+		"reserved cells are skipped": {
+			from: NewTimedCoordinate(NewCoordinate(1, 1), 0),
+			e:    []TimedCoordinate{NewTimedCoordinate(NewCoordinate(1, 1), 1)},
+		},
+		"swapping places is not allowed": {
+			from: NewTimedCoordinate(NewCoordinate(2, 1), 0),
+			e: []TimedCoordinate{
+				NewTimedCoordinate(NewCoordinate(1, 1), 1),
+			},
+		},
+		"parked agents block their cell for good": {
+			from: NewTimedCoordinate(NewCoordinate(2, 1), 10),
+			e: []TimedCoordinate{
+				NewTimedCoordinate(NewCoordinate(3, 1), 11),
+				NewTimedCoordinate(NewCoordinate(2, 1), 11),
+			},
+		},
+	}
+
+	for name, td := range tests {
+		td := td
+		t.Run(name, func(t *testing.T) {
+			actual := adapter.Neighbours(td.from)
+			if len(actual) != len(td.e) || !slices.Equal(actual, td.e) {
+				t.Errorf("expected neighbours of %+v to be %+v but received %+v", td.from, td.e, actual)
+			}
+		})
+	}
+}
+
+func TestCooperativeAdapterIsFinish(t *testing.T) {
+	a, err := Parse(corridor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	finish := NewCoordinate(5, 1)
+	table := NewReservationTable()
+	table.Reserve([]TimedCoordinate{
+		NewTimedCoordinate(finish, 3),
+		NewTimedCoordinate(NewCoordinate(4, 1), 4),
+	})
+
+	adapter := NewCooperativeAdapter(a, table, finish)
+
+	if adapter.IsFinish(NewTimedCoordinate(finish, 2)) {
+		t.Error("expected finish to be unavailable while another agent passes through later on")
+	}
+
+	if !adapter.IsFinish(NewTimedCoordinate(finish, 4)) {
+		t.Error("expected finish to be reached once no other agent needs it anymore")
+	}
+}
@@ -1,6 +1,14 @@
 package arena
 
-import "math"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var ErrorInvalidCoordinate = errors.New("invalid coordinate: expected x,y")
 
 type Coordinate struct {
 	x, y int
@@ -10,6 +18,30 @@ func NewCoordinate(x, y int) Coordinate {
 	return Coordinate{x: x, y: y}
 }
 
+// parses a coordinate written as "x,y", the way String writes them.
+func ParseCoordinate(s string) (Coordinate, error) {
+	xs, ys, found := strings.Cut(strings.TrimSpace(s), ",")
+	if !found {
+		return Coordinate{}, ErrorInvalidCoordinate
+	}
+
+	x, err := strconv.Atoi(strings.TrimSpace(xs))
+	if err != nil {
+		return Coordinate{}, ErrorInvalidCoordinate
+	}
+
+	y, err := strconv.Atoi(strings.TrimSpace(ys))
+	if err != nil {
+		return Coordinate{}, ErrorInvalidCoordinate
+	}
+
+	return NewCoordinate(x, y), nil
+}
+
+func (c Coordinate) String() string {
+	return fmt.Sprintf("%d,%d", c.x, c.y)
+}
+
 func (c Coordinate) DistanceTo(t Coordinate) int {
 	n := math.Abs(float64(c.x-t.x)) + math.Abs(float64(c.y-t.y))
 	return int(n)
@@ -73,3 +73,54 @@ func TestCoordinateMove(t *testing.T) {
 		})
 	}
 }
+
+func TestParseCoordinate(t *testing.T) {
+	tests := map[string]struct {
+		s   string
+		e   Coordinate
+		err error
+	}{
+		"plain": {
+			s: "3,4",
+			e: NewCoordinate(3, 4),
+		},
+		"with spaces": {
+			s: " 3, 4 ",
+			e: NewCoordinate(3, 4),
+		},
+		"missing comma": {
+			s:   "34",
+			err: ErrorInvalidCoordinate,
+		},
+		"not a number": {
+			s:   "3,four",
+			err: ErrorInvalidCoordinate,
+		},
+	}
+
+	for name, td := range tests {
+		td := td
+		t.Run(name, func(t *testing.T) {
+			actual, err := ParseCoordinate(td.s)
+			if err != td.err {
+				t.Fatalf("expected error %v but got %v", td.err, err)
+			}
+
+			if actual != td.e {
+				t.Errorf("expected %+v but got %+v", td.e, actual)
+			}
+		})
+	}
+}
+
+func TestCoordinateString(t *testing.T) {
+	c := NewCoordinate(3, 4)
+	if c.String() != "3,4" {
+		t.Errorf("expected 3,4 but got %s", c.String())
+	}
+
+	parsed, err := ParseCoordinate(c.String())
+	if err != nil || parsed != c {
+		t.Errorf("expected %s to parse back into %+v, got %+v (%v)", c.String(), c, parsed, err)
+	}
+}
@@ -0,0 +1,153 @@
+package arena
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// the set of keys an agent carries, one bit for each of the keys a to z.
+type KeySet uint32
+
+func (k KeySet) Has(key rune) bool {
+	return k&keyBit(key) != 0
+}
+
+func (k KeySet) With(key rune) KeySet {
+	return k | keyBit(key)
+}
+
+func keyBit(key rune) KeySet {
+	return 1 << (unicode.ToLower(key) - 'a')
+}
+
+// a Coordinate along with the keys collected before reaching it.
+type KeyState struct {
+	Coordinate
+	Keys KeySet
+}
+
+// returns the key lying at the given coordinate, if any.
+func (m *Arena) KeyAt(c Coordinate) (rune, bool) {
+	if m.CellTypeForCoordinate(c) != CellTypeKey {
+		return 0, false
+	}
+
+	return rune(m.labels[c][0]), true
+}
+
+// returns the key that opens the door at the given coordinate, if any.
+func (m *Arena) DoorAt(c Coordinate) (rune, bool) {
+	if m.CellTypeForCoordinate(c) != CellTypeDoor {
+		return 0, false
+	}
+
+	return unicode.ToLower(rune(m.labels[c][0])), true
+}
+
+func (m *Arena) HasDoors() bool {
+	for c := range m.labels {
+		if _, found := m.DoorAt(c); found {
+			return true
+		}
+	}
+
+	return false
+}
+
+// renders the arena with the path like RenderWithPath does, keeping keys and
+// doors visible, followed by the order in which the keys were collected.
+func (m *Arena) RenderWithKeyPath(w io.Writer, path []KeyState) {
+	for y := range m.cells {
+		if y > 0 {
+			fmt.Fprintf(w, "\n")
+		}
+
+		for x := range m.cells[y] {
+			c := Coordinate{x: x, y: y}
+			_, labelled := m.labels[c]
+
+			if !labelled && containsCoordinate(path, c) {
+				fmt.Fprintf(w, "%s", SymbolPath)
+			} else {
+				fmt.Fprintf(w, "%s", m.symbolAt(c))
+			}
+		}
+	}
+
+	keys := CollectedKeys(path)
+	if len(keys) > 0 {
+		fmt.Fprintf(w, "\n\nkeys collected: %s", strings.Join(strings.Split(string(keys), ""), " → "))
+	}
+}
+
+func containsCoordinate(path []KeyState, c Coordinate) bool {
+	for _, s := range path {
+		if s.Coordinate == c {
+			return true
+		}
+	}
+
+	return false
+}
+
+// returns the keys in the order they were collected along the path. Like
+// Solver.Walk returns it, the path is expected to run from finish to start.
+func CollectedKeys(path []KeyState) []rune {
+	keys := []rune{}
+
+	for idx := len(path) - 2; idx >= 0; idx-- {
+		gained := path[idx].Keys &^ path[idx+1].Keys
+		for key := 'a'; key <= 'z'; key++ {
+			if gained.Has(key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	return keys
+}
+
+// KeyAdapter solves arenas with keys and doors, where a door can only be
+// passed after collecting the key of the same letter. Its candidates carry
+// the keys collected so far, so the same cell can be visited again once more
+// keys are in hand. Start searching from a KeyState without any keys.
+type KeyAdapter struct {
+	arena *Arena
+}
+
+func NewKeyAdapter(a *Arena) *KeyAdapter {
+	return &KeyAdapter{arena: a}
+}
+
+func (k *KeyAdapter) Neighbours(s KeyState) []KeyState {
+	neighbours := []KeyState{}
+
+	for _, c := range k.arena.NeighboursOfCoordinate(s.Coordinate) {
+		if door, found := k.arena.DoorAt(c); found {
+			if !s.Keys.Has(door) {
+				continue
+			}
+		} else if !k.arena.IsWalkable(c) {
+			continue
+		}
+
+		keys := s.Keys
+		if key, found := k.arena.KeyAt(c); found {
+			keys = keys.With(key)
+		}
+
+		neighbours = append(neighbours, KeyState{Coordinate: c, Keys: keys})
+	}
+
+	return neighbours
+}
+
+func (k *KeyAdapter) CostToFinish(s KeyState) int {
+	return s.DistanceTo(k.arena.FinishCoordinate())
+}
+
+func (k *KeyAdapter) IsFinish(s KeyState) bool {
+	return s.Coordinate == k.arena.FinishCoordinate()
+}
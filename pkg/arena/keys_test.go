@@ -0,0 +1,141 @@
+package arena
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/tmw/pathfind"
+	"github.com/tmw/pathfind/pkg/slice"
+)
+
+const keysInput = `
+#########
+#b.S.A.F#
+####.####
+#...a.B.#
+#########
+`
+
+// makes the given letters parse as keys and doors for the rest of the test.
+func withKeys(t *testing.T, keys string) {
+	previous := SymbolKeys
+	SymbolKeys = keys
+	t.Cleanup(func() { SymbolKeys = previous })
+}
+
+func TestParseKeysAndDoors(t *testing.T) {
+	withKeys(t, "ab")
+
+	a, err := Parse(keysInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if key, found := a.KeyAt(NewCoordinate(4, 3)); !found || key != 'a' {
+		t.Errorf("expected key a at (4, 3), got %q", key)
+	}
+
+	if key, found := a.DoorAt(NewCoordinate(5, 1)); !found || key != 'a' {
+		t.Errorf("expected door opened by key a at (5, 1), got %q", key)
+	}
+
+	if a.IsWalkable(NewCoordinate(5, 1)) {
+		t.Error("expected doors to not be walkable")
+	}
+
+	if !a.HasDoors() {
+		t.Error("expected arena to have doors")
+	}
+
+	var out strings.Builder
+	a.Render(&out)
+	compare(t, out.String(), strings.TrimSpace(keysInput))
+}
+
+func TestParseLettersWithoutKeys(t *testing.T) {
+	withKeys(t, "b")
+
+	a, err := Parse(keysInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, found := a.KeyAt(NewCoordinate(4, 3)); found || !a.IsWalkable(NewCoordinate(4, 3)) {
+		t.Error("expected a to be walkable when not listed as a key")
+	}
+
+	if _, found := a.DoorAt(NewCoordinate(5, 1)); found || !a.IsWalkable(NewCoordinate(5, 1)) {
+		t.Error("expected A to be walkable when a is not listed as a key")
+	}
+
+	if _, found := a.DoorAt(NewCoordinate(6, 3)); !found {
+		t.Error("expected B to be a door when b is listed as a key")
+	}
+}
+
+func TestKeyAdapter(t *testing.T) {
+	withKeys(t, "ab")
+
+	a, err := Parse(keysInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, algorithm := range []pathfind.Algorithm{pathfind.AlgorithmBFS, pathfind.AlgorithmAStar} {
+		solver := pathfind.NewSolver[KeyState](
+			algorithm,
+			KeyState{Coordinate: a.StartCoordinate()},
+			NewKeyAdapter(a),
+		)
+
+		path := solver.Walk()
+		if len(path) == 0 {
+			t.Fatalf("algorithm %d: expected a path but found none", algorithm)
+		}
+
+		// walk down to key a and back up through door A.
+		if len(path) != 9 {
+			t.Errorf("algorithm %d: expected a path of 9 steps, got %d", algorithm, len(path))
+		}
+
+		if keys := CollectedKeys(path); !slices.Equal(keys, []rune{'a'}) {
+			t.Errorf("algorithm %d: expected to collect key a, got %q", algorithm, keys)
+		}
+
+		coordinates := slice.Map(path, func(s KeyState) Coordinate { return s.Coordinate })
+		if slices.Contains(coordinates, NewCoordinate(6, 3)) {
+			t.Errorf("algorithm %d: expected path to not pass locked door B", algorithm)
+		}
+	}
+}
+
+func TestRenderWithKeyPath(t *testing.T) {
+	withKeys(t, "ab")
+
+	a, err := Parse(keysInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := []KeyState{
+		{Coordinate: NewCoordinate(3, 1)},
+		{Coordinate: NewCoordinate(2, 1)},
+		{Coordinate: NewCoordinate(1, 1), Keys: KeySet(0).With('b')},
+	}
+	slices.Reverse(path)
+
+	var out strings.Builder
+	a.RenderWithKeyPath(&out, path)
+
+	expected := "" +
+		"#########\n" +
+		"#b@@.A.F#\n" +
+		"####.####\n" +
+		"#...a.B.#\n" +
+		"#########\n" +
+		"\n" +
+		"keys collected: b"
+
+	compare(t, out.String(), expected)
+}
@@ -2,16 +2,18 @@ package arena
 
 import (
 	"errors"
+	"slices"
 	"strings"
 
 	"github.com/tmw/pathfind/pkg/slice"
 )
 
 var (
-	ErrorInvalidArenaNoStart        = errors.New("invalid map: no start cell found")
-	ErrorInvalidArenaNoFinish       = errors.New("invalid map: no finish cell found")
-	ErrorInvalidArenaMultipleStart  = errors.New("invalid map: multiple start cells found")
-	ErrorInvalidArenaMultipleFinish = errors.New("invalid map: multiple finish cells found")
+	ErrorInvalidArenaNoStart           = errors.New("invalid map: no start cell found")
+	ErrorInvalidArenaNoFinish          = errors.New("invalid map: no finish cell found")
+	ErrorInvalidArenaMultipleStart     = errors.New("invalid map: multiple start cells found")
+	ErrorInvalidArenaMultipleFinish    = errors.New("invalid map: multiple finish cells found")
+	ErrorInvalidArenaDuplicateWaypoint = errors.New("invalid map: waypoint number used more than once")
 )
 
 var (
@@ -20,24 +22,59 @@ var (
 	SymbolStart       = "S"
 	SymbolFinish      = "F"
 	SymbolPath        = "@"
+
+	// the lowercase letters marking keys, each opening the doors marked by
+	// the same letter in uppercase. Empty by default, leaving letters to
+	// parse as walkable cells.
+	SymbolKeys = ""
 )
 
 func Parse(input string) (*Arena, error) {
 	lines := strings.Split(strings.TrimSpace(input), "\n")
 	cells := make([][]CellType, len(lines))
-	for i := range cells {
-		cells[i] = slice.Map(strings.Split(lines[i], ""), symbolToType)
+	labels := make(map[Coordinate]string)
+	for y := range cells {
+		symbols := strings.Split(lines[y], "")
+		cells[y] = slice.Map(symbols, symbolToType)
+
+		for x := range cells[y] {
+			if cells[y][x].isLabelled() {
+				labels[Coordinate{x: x, y: y}] = symbols[x]
+			}
+		}
 	}
 
+	return newArena(cells, labels)
+}
+
+// creates an arena from the given cells, which must contain exactly one start
+// and one finish cell. Waypoints, keys and doors are told apart by their
+// symbol, so arenas containing those need to be created using Parse.
+func New(cells [][]CellType) (*Arena, error) {
+	return newArena(cells, make(map[Coordinate]string))
+}
+
+func newArena(cells [][]CellType, labels map[Coordinate]string) (*Arena, error) {
 	start, stop, err := findStartAndFinish(cells)
 	if err != nil {
 		return nil, err
 	}
 
+	waypoints, err := findWaypoints(labels)
+	if err != nil {
+		return nil, err
+	}
+
 	m := &Arena{
 		cells:      cells,
+		labels:     labels,
 		startCell:  *start,
 		finishCell: *stop,
+		waypoints:  waypoints,
+	}
+
+	for y := range cells {
+		m.width = max(m.width, len(cells[y]))
 	}
 
 	return m, nil
@@ -80,3 +117,29 @@ func findStartAndFinish(cells [][]CellType) (*Coordinate, *Coordinate, error) {
 
 	return start, finish, nil
 }
+
+// returns the coordinates of the waypoints, ordered by their number.
+func findWaypoints(labels map[Coordinate]string) ([]Coordinate, error) {
+	numbered := make(map[string]Coordinate)
+	for c, label := range labels {
+		if !isWaypointSymbol(label) {
+			continue
+		}
+
+		if _, found := numbered[label]; found {
+			return nil, ErrorInvalidArenaDuplicateWaypoint
+		}
+
+		numbered[label] = c
+	}
+
+	numbers := make([]string, 0, len(numbered))
+	for number := range numbered {
+		numbers = append(numbers, number)
+	}
+	slices.Sort(numbers)
+
+	return slice.Map(numbers, func(number string) Coordinate {
+		return numbered[number]
+	}), nil
+}
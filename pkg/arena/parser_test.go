@@ -1,6 +1,7 @@
 package arena
 
 import (
+	"slices"
 	"strings"
 	"testing"
 )
@@ -107,6 +108,48 @@ func TestParseValdiation_MultipleFinish(t *testing.T) {
 	}
 }
 
+func TestParseWaypoints(t *testing.T) {
+	const mapInput = "" +
+		"##########\n" +
+		"#S..2....#\n" +
+		"#........#\n" +
+		"#.1....3.#\n" +
+		"#.......F#\n" +
+		"##########"
+
+	m, err := Parse(mapInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Coordinate{
+		NewCoordinate(2, 3),
+		NewCoordinate(4, 1),
+		NewCoordinate(7, 3),
+	}
+
+	if actual := m.Waypoints(); !slices.Equal(actual, expected) {
+		t.Errorf("expected waypoints %+v but got %+v", expected, actual)
+	}
+
+	var out strings.Builder
+	m.Render(&out)
+	compare(t, out.String(), mapInput)
+}
+
+func TestParseValdiation_DuplicateWaypoint(t *testing.T) {
+	const mapInput = "" +
+		"##########\n" +
+		"#S..1....#\n" +
+		"#.1.....F#\n" +
+		"##########"
+
+	_, err := Parse(mapInput)
+	if err != ErrorInvalidArenaDuplicateWaypoint {
+		t.Errorf("expected InvalidArenaDuplicateWaypoint error but got %v", err)
+	}
+}
+
 func compare(t *testing.T, a, b string) {
 	if len(a) != len(b) {
 		t.Errorf("lengths do not match. len(a) = %d; len(b) = %d\n", len(a), len(b))
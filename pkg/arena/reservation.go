@@ -0,0 +1,86 @@
+package arena
+
+import (
+	"cmp"
+	"slices"
+)
+
+type timedEdge struct {
+	from, to TimedCoordinate
+}
+
+// keeps track of the cells and moves claimed by agents that have already
+// been planned, so that agents planned after them can steer clear.
+type ReservationTable struct {
+	cells  map[TimedCoordinate]struct{}
+	edges  map[timedEdge]struct{}
+	latest map[Coordinate]int
+	parked map[Coordinate]int
+}
+
+func NewReservationTable() *ReservationTable {
+	return &ReservationTable{
+		cells:  make(map[TimedCoordinate]struct{}),
+		edges:  make(map[timedEdge]struct{}),
+		latest: make(map[Coordinate]int),
+		parked: make(map[Coordinate]int),
+	}
+}
+
+// reserves every step of an agent's path. The path may be given in either
+// direction, so the output of Solver.Walk can be passed in as is. The agent
+// is assumed to stay at its final cell, which remains reserved from then on.
+func (r *ReservationTable) Reserve(path []TimedCoordinate) {
+	steps := slices.Clone(path)
+	slices.SortFunc(steps, func(a, b TimedCoordinate) int {
+		return cmp.Compare(a.Tick, b.Tick)
+	})
+
+	for idx, step := range steps {
+		r.cells[step] = struct{}{}
+		r.latest[step.Coordinate] = max(r.latest[step.Coordinate], step.Tick)
+
+		if idx > 0 {
+			r.edges[timedEdge{from: steps[idx-1], to: step}] = struct{}{}
+		}
+	}
+
+	if len(steps) > 0 {
+		last := steps[len(steps)-1]
+		r.parked[last.Coordinate] = last.Tick
+	}
+}
+
+// returns true if the cell is taken by another agent at the given tick.
+func (r *ReservationTable) IsReserved(t TimedCoordinate) bool {
+	if _, found := r.cells[t]; found {
+		return true
+	}
+
+	tick, found := r.parked[t.Coordinate]
+	return found && t.Tick >= tick
+}
+
+// returns true if moving from -> to would make the agent swap places with
+// another agent, which would otherwise go unnoticed as the two never share a
+// cell at the same tick.
+func (r *ReservationTable) IsSwap(from, to TimedCoordinate) bool {
+	opposite := timedEdge{
+		from: NewTimedCoordinate(to.Coordinate, from.Tick),
+		to:   NewTimedCoordinate(from.Coordinate, to.Tick),
+	}
+
+	_, found := r.edges[opposite]
+	return found
+}
+
+// returns true if any agent claims the cell of t after t's tick, meaning an
+// agent can not come to rest there.
+func (r *ReservationTable) isReservedAfter(t TimedCoordinate) bool {
+	if _, found := r.parked[t.Coordinate]; found {
+		return true
+	}
+
+	tick, found := r.latest[t.Coordinate]
+	return found && tick > t.Tick
+}
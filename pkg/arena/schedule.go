@@ -0,0 +1,102 @@
+package arena
+
+import "errors"
+
+var ErrorInvalidCycle = errors.New("invalid cycle: length must be positive, offsets within 0 and length")
+
+type cycle struct {
+	length  int
+	offsets []int
+}
+
+// Schedule describes when cells are blocked, for obstacles that come and go
+// over time such as moving platforms or patrolling guards. Cells without an
+// entry in the schedule are never blocked by it.
+type Schedule struct {
+	ticks  map[Coordinate]map[int]struct{}
+	cycles map[Coordinate][]cycle
+}
+
+func NewSchedule() *Schedule {
+	return &Schedule{
+		ticks:  make(map[Coordinate]map[int]struct{}),
+		cycles: make(map[Coordinate][]cycle),
+	}
+}
+
+// blocks the cell at exactly the given ticks.
+func (s *Schedule) Block(c Coordinate, ticks ...int) {
+	if _, found := s.ticks[c]; !found {
+		s.ticks[c] = make(map[int]struct{})
+	}
+
+	for _, tick := range ticks {
+		s.ticks[c][tick] = struct{}{}
+	}
+}
+
+// blocks the cell at every tick for which tick % length equals one of the
+// offsets, e.g. a guard passing by every 4 ticks. Returns ErrorInvalidCycle,
+// leaving the schedule as is, unless length is positive and every offset is
+// at least 0 and less than length.
+func (s *Schedule) BlockEvery(c Coordinate, length int, offsets ...int) error {
+	if length <= 0 {
+		return ErrorInvalidCycle
+	}
+
+	for _, offset := range offsets {
+		if offset < 0 || offset >= length {
+			return ErrorInvalidCycle
+		}
+	}
+
+	s.cycles[c] = append(s.cycles[c], cycle{length: length, offsets: offsets})
+	return nil
+}
+
+func (s *Schedule) IsBlocked(c Coordinate, tick int) bool {
+	if _, found := s.ticks[c][tick]; found {
+		return true
+	}
+
+	for _, cy := range s.cycles[c] {
+		for _, offset := range cy.offsets {
+			if tick%cy.length == offset {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ScheduleAdapter searches the arena through space and time, only entering
+// cells that are not blocked by the schedule at the tick they are entered.
+// As every move (including waiting in place) costs one tick, solving from
+// the start at tick 0 yields the earliest arrival at the finish. The search
+// space is unbounded in time, so set a MaxCost on the solver.
+type ScheduleAdapter struct {
+	arena    *Arena
+	schedule *Schedule
+}
+
+func NewScheduleAdapter(a *Arena, s *Schedule) *ScheduleAdapter {
+	return &ScheduleAdapter{
+		arena:    a,
+		schedule: s,
+	}
+}
+
+func (s *ScheduleAdapter) Neighbours(t TimedCoordinate) []TimedCoordinate {
+	return s.arena.timedNeighbours(t, func(_, to TimedCoordinate) bool {
+		return !s.schedule.IsBlocked(to.Coordinate, to.Tick)
+	})
+}
+
+func (s *ScheduleAdapter) CostToFinish(t TimedCoordinate) int {
+	return t.DistanceTo(s.arena.FinishCoordinate())
+}
+
+func (s *ScheduleAdapter) IsFinish(t TimedCoordinate) bool {
+	return t.Coordinate == s.arena.FinishCoordinate()
+}
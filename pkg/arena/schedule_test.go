@@ -0,0 +1,84 @@
+package arena
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tmw/pathfind"
+)
+
+func TestScheduleIsBlocked(t *testing.T) {
+	c := NewCoordinate(3, 1)
+
+	s := NewSchedule()
+	s.Block(c, 2, 5)
+	if err := s.BlockEvery(c, 4, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.BlockEvery(c, 0, 0); !errors.Is(err, ErrorInvalidCycle) {
+		t.Errorf("expected ErrorInvalidCycle for length 0, got %v", err)
+	}
+	if err := s.BlockEvery(c, 4, 4); !errors.Is(err, ErrorInvalidCycle) {
+		t.Errorf("expected ErrorInvalidCycle for an offset equal to the length, got %v", err)
+	}
+	if err := s.BlockEvery(c, 4, -1); !errors.Is(err, ErrorInvalidCycle) {
+		t.Errorf("expected ErrorInvalidCycle for a negative offset, got %v", err)
+	}
+
+	tests := map[string]struct {
+		c    Coordinate
+		tick int
+		e    bool
+	}{
+		"blocked at exact tick":        {c: c, tick: 5, e: true},
+		"blocked by cycle":             {c: c, tick: 8, e: true},
+		"blocked at cycle start":       {c: c, tick: 0, e: true},
+		"free in between":              {c: c, tick: 3, e: false},
+		"rejected cycles are left out": {c: c, tick: 1, e: false},
+		"free after exact ticks":       {c: c, tick: 7, e: false},
+		"other cells are not hurt":     {c: c.East(), tick: 2, e: false},
+	}
+
+	for name, td := range tests {
+		td := td
+		t.Run(name, func(t *testing.T) {
+			if actual := s.IsBlocked(td.c, td.tick); actual != td.e {
+				t.Errorf("expected IsBlocked(%+v, %d) to be %t", td.c, td.tick, td.e)
+			}
+		})
+	}
+}
+
+func TestScheduleAdapterWaitsForObstacle(t *testing.T) {
+	a, err := Parse(corridor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a guard stands in the middle of the corridor until tick 5.
+	s := NewSchedule()
+	s.Block(NewCoordinate(3, 1), 0, 1, 2, 3, 4, 5)
+
+	solver := pathfind.NewSolver[TimedCoordinate](
+		pathfind.AlgorithmAStar,
+		NewTimedCoordinate(a.StartCoordinate(), 0),
+		NewScheduleAdapter(a, s),
+	)
+	solver.MaxCost = 20
+
+	path := solver.Walk()
+	if len(path) == 0 {
+		t.Fatal("expected a path but found none")
+	}
+
+	if arrival := path[0]; arrival.Coordinate != a.FinishCoordinate() || arrival.Tick != 8 {
+		t.Errorf("expected to arrive at the finish on tick 8, got %+v", arrival)
+	}
+
+	for _, step := range path {
+		if s.IsBlocked(step.Coordinate, step.Tick) {
+			t.Errorf("path enters blocked cell %+v", step)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package arena
+
+// a Coordinate at a given tick, for searching through both space and time.
+type TimedCoordinate struct {
+	Coordinate
+	Tick int
+}
+
+func NewTimedCoordinate(c Coordinate, tick int) TimedCoordinate {
+	return TimedCoordinate{Coordinate: c, Tick: tick}
+}
+
+// returns the moves available from t, one tick later: stepping onto any
+// walkable neighbour or waiting in place. allowed can veto individual moves
+// for reasons other than the layout of the arena.
+func (m *Arena) timedNeighbours(t TimedCoordinate, allowed func(from, to TimedCoordinate) bool) []TimedCoordinate {
+	moves := append(m.NeighboursOfCoordinate(t.Coordinate), t.Coordinate)
+
+	neighbours := []TimedCoordinate{}
+	for _, c := range moves {
+		next := NewTimedCoordinate(c, t.Tick+1)
+		if m.IsWalkable(c) && allowed(t, next) {
+			neighbours = append(neighbours, next)
+		}
+	}
+
+	return neighbours
+}
@@ -0,0 +1,52 @@
+package bitset
+
+import (
+	"math/bits"
+)
+
+// a set of non-negative integers, one bit each. It grows to fit the largest
+// integer added.
+type Bitset struct {
+	words []uint64
+}
+
+// returns a set with room for the integers 0 up to size without growing.
+func New(size int) *Bitset {
+	return &Bitset{words: make([]uint64, (size+63)/64)}
+}
+
+func (b *Bitset) Set(i int) {
+	word := i / 64
+	if word >= len(b.words) {
+		words := make([]uint64, max(word+1, 2*len(b.words)))
+		copy(words, b.words)
+		b.words = words
+	}
+
+	b.words[word] |= 1 << (i % 64)
+}
+
+func (b *Bitset) Unset(i int) {
+	if word := i / 64; word < len(b.words) {
+		b.words[word] &^= 1 << (i % 64)
+	}
+}
+
+func (b *Bitset) Has(i int) bool {
+	word := i / 64
+	return word < len(b.words) && b.words[word]&(1<<(i%64)) != 0
+}
+
+// returns the number of integers in the set.
+func (b *Bitset) Count() int {
+	count := 0
+	for _, w := range b.words {
+		count += bits.OnesCount64(w)
+	}
+	return count
+}
+
+// empties the set, keeping its capacity.
+func (b *Bitset) Clear() {
+	clear(b.words)
+}
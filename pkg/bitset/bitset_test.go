@@ -0,0 +1,63 @@
+package bitset
+
+import (
+	"testing"
+)
+
+func TestSetAndHas(t *testing.T) {
+	b := New(10)
+	b.Set(3)
+	b.Set(64)
+
+	for _, i := range []int{3, 64} {
+		if !b.Has(i) {
+			t.Errorf("expected %d to be set", i)
+		}
+	}
+
+	for _, i := range []int{0, 4, 63, 65, 1000} {
+		if b.Has(i) {
+			t.Errorf("expected %d not to be set", i)
+		}
+	}
+}
+
+func TestGrows(t *testing.T) {
+	b := New(0)
+	b.Set(1000)
+
+	if !b.Has(1000) || b.Count() != 1 {
+		t.Errorf("expected only 1000 to be set, got a count of %d", b.Count())
+	}
+}
+
+func TestUnset(t *testing.T) {
+	b := New(128)
+	b.Set(5)
+	b.Set(100)
+	b.Unset(5)
+	b.Unset(5000)
+
+	if b.Has(5) {
+		t.Error("expected 5 to be unset")
+	}
+
+	if b.Count() != 1 {
+		t.Errorf("expected a count of 1, got %d", b.Count())
+	}
+}
+
+func TestClear(t *testing.T) {
+	b := New(128)
+	b.Set(5)
+	b.Set(100)
+	b.Clear()
+
+	if b.Count() != 0 {
+		t.Errorf("expected an empty set, got a count of %d", b.Count())
+	}
+
+	if len(b.words) != 2 {
+		t.Errorf("expected capacity to be kept, got %d words", len(b.words))
+	}
+}
@@ -0,0 +1,221 @@
+package ch
+
+import (
+	"github.com/tmw/pathfind/pkg/prioqueue"
+)
+
+// stops a witness search after settling this many nodes, adding a shortcut
+// that might not have been needed. Keeps preprocessing fast on dense graphs.
+const witnessSettleLimit = 64
+
+type contraction struct {
+	out     []map[int]arc
+	in      []map[int]arc
+	deleted []int
+
+	// scratch space for witness searches, reused between searches. Entries
+	// are only valid when their stamp matches the current search.
+	dist   []int
+	stamp  []int
+	search int
+}
+
+// preprocesses the graph formed by the given edges into a Hierarchy.
+func Build[T comparable](edges []Edge[T]) *Hierarchy[T] {
+	nodes := []T{}
+	index := make(map[T]int)
+	indexOf := func(n T) int {
+		if i, found := index[n]; found {
+			return i
+		}
+		index[n] = len(nodes)
+		nodes = append(nodes, n)
+		return index[n]
+	}
+
+	for _, e := range edges {
+		indexOf(e.From)
+		indexOf(e.To)
+	}
+
+	c := &contraction{
+		out:     make([]map[int]arc, len(nodes)),
+		in:      make([]map[int]arc, len(nodes)),
+		deleted: make([]int, len(nodes)),
+		dist:    make([]int, len(nodes)),
+		stamp:   make([]int, len(nodes)),
+	}
+
+	for i := range nodes {
+		c.out[i] = make(map[int]arc)
+		c.in[i] = make(map[int]arc)
+	}
+
+	for _, e := range edges {
+		from, to := index[e.From], index[e.To]
+		if from != to {
+			c.addEdge(from, to, e.Cost, -1)
+		}
+	}
+
+	up := make([][]arc, len(nodes))
+	down := make([][]arc, len(nodes))
+
+	queue := prioqueue.New[int]()
+	for v := range nodes {
+		queue.Push(v, c.priority(v, c.shortcuts(v)))
+	}
+
+	for queue.Len() > 0 {
+		v := queue.Pop()
+
+		// priorities go stale as neighbours get contracted, so re-evaluate
+		// lazily and put the node back if it's no longer the least important.
+		shortcuts := c.shortcuts(v)
+		if p := c.priority(v, shortcuts); queue.Len() > 0 && p > queue.PriorityOfItem(0) {
+			queue.Push(v, p)
+			continue
+		}
+
+		for _, a := range c.out[v] {
+			up[v] = append(up[v], a)
+		}
+
+		for _, a := range c.in[v] {
+			down[v] = append(down[v], a)
+		}
+
+		for _, s := range shortcuts {
+			c.addEdge(s.from, s.To, s.Cost, s.Via)
+		}
+
+		c.remove(v)
+	}
+
+	return newHierarchy(nodes, up, down)
+}
+
+// keeps only the cheapest edge between any two nodes.
+func (c *contraction) addEdge(from, to, cost, via int) {
+	if existing, found := c.out[from][to]; found && existing.Cost <= cost {
+		return
+	}
+
+	c.out[from][to] = arc{To: to, Cost: cost, Via: via}
+	c.in[to][from] = arc{To: from, Cost: cost, Via: via}
+}
+
+func (c *contraction) remove(v int) {
+	for w := range c.out[v] {
+		delete(c.in[w], v)
+		c.deleted[w]++
+	}
+
+	for u := range c.in[v] {
+		delete(c.out[u], v)
+		c.deleted[u]++
+	}
+}
+
+// the edge difference of contracting v, plus the number of neighbours that
+// were contracted already to spread contraction evenly over the graph.
+func (c *contraction) priority(v int, shortcuts []shortcut) int {
+	return len(shortcuts) - len(c.in[v]) - len(c.out[v]) + c.deleted[v]
+}
+
+type shortcut struct {
+	arc
+	from int
+}
+
+// returns the shortcuts needed to preserve shortest paths through v, were v
+// to be removed from the graph.
+func (c *contraction) shortcuts(v int) []shortcut {
+	shortcuts := []shortcut{}
+
+	for u, in := range c.in[v] {
+		limit := 0
+		for w, out := range c.out[v] {
+			if w != u {
+				limit = max(limit, in.Cost+out.Cost)
+			}
+		}
+
+		c.witnessSearch(u, v, limit, c.out[v])
+
+		for w, out := range c.out[v] {
+			if w == u {
+				continue
+			}
+
+			cost := in.Cost + out.Cost
+			if d, found := c.witnessDistance(w); found && d <= cost {
+				continue
+			}
+
+			shortcuts = append(shortcuts, shortcut{
+				arc:  arc{To: w, Cost: cost, Via: v},
+				from: u,
+			})
+		}
+	}
+
+	return shortcuts
+}
+
+// runs a bounded Dijkstra search from source that avoids node skip, finding
+// distances up to limit. Stops early once all targets are settled.
+func (c *contraction) witnessSearch(source, skip, limit int, targets map[int]arc) {
+	c.search++
+	c.setWitnessDistance(source, 0)
+
+	settled, remaining := 0, len(targets)
+
+	queue := prioqueue.New[int]()
+	queue.Push(source, 0)
+
+	for queue.Len() > 0 && settled < witnessSettleLimit {
+		d := queue.PriorityOfItem(0)
+		u := queue.Pop()
+
+		if d > limit {
+			break
+		}
+
+		// skip stale queue entries.
+		if known, _ := c.witnessDistance(u); d > known {
+			continue
+		}
+		settled++
+
+		if _, found := targets[u]; found {
+			if remaining--; remaining == 0 {
+				break
+			}
+		}
+
+		for w, a := range c.out[u] {
+			if w == skip {
+				continue
+			}
+
+			if known, found := c.witnessDistance(w); !found || d+a.Cost < known {
+				c.setWitnessDistance(w, d+a.Cost)
+				queue.Push(w, d+a.Cost)
+			}
+		}
+	}
+}
+
+func (c *contraction) witnessDistance(v int) (int, bool) {
+	if c.stamp[v] != c.search {
+		return 0, false
+	}
+
+	return c.dist[v], true
+}
+
+func (c *contraction) setWitnessDistance(v, d int) {
+	c.stamp[v] = c.search
+	c.dist[v] = d
+}
@@ -0,0 +1,62 @@
+// Package ch implements contraction hierarchies, answering repeated shortest
+// path queries on a static weighted graph much faster than a plain search.
+//
+// Building a Hierarchy contracts the nodes one by one, in order of
+// importance, adding shortcut edges wherever a contracted node was on the
+// only shortest path between its neighbours. Queries then only ever need to
+// move up the hierarchy, from both ends, which touches very few nodes.
+package ch
+
+import (
+	"errors"
+)
+
+var (
+	ErrorUnknownNode = errors.New("ch: unknown node")
+	ErrorNoPath      = errors.New("ch: no path found")
+)
+
+// a directed edge of the input graph. Add edges in both directions for
+// undirected graphs. Costs must not be negative.
+type Edge[T comparable] struct {
+	From T
+	To   T
+	Cost int
+}
+
+// an edge of the contracted graph, pointing at node To. Shortcuts skip over
+// node Via, original edges have Via set to -1.
+type arc struct {
+	To   int
+	Cost int
+	Via  int
+}
+
+type Hierarchy[T comparable] struct {
+	nodes []T
+	index map[T]int
+
+	// up[v] holds the edges v -> w towards nodes ranked higher than v.
+	// down[v] holds the edges u -> v from nodes ranked higher than v,
+	// with arc.To pointing at u, for searching backwards from the target.
+	up   [][]arc
+	down [][]arc
+}
+
+func (h *Hierarchy[T]) Len() int {
+	return len(h.nodes)
+}
+
+func newHierarchy[T comparable](nodes []T, up, down [][]arc) *Hierarchy[T] {
+	index := make(map[T]int, len(nodes))
+	for i, n := range nodes {
+		index[n] = i
+	}
+
+	return &Hierarchy[T]{
+		nodes: nodes,
+		index: index,
+		up:    up,
+		down:  down,
+	}
+}
@@ -0,0 +1,147 @@
+package ch
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+type pair struct{ from, to int }
+
+func randomEdges(nodes, edges int, seed int64) []Edge[int] {
+	r := rand.New(rand.NewSource(seed))
+	out := make([]Edge[int], 0, edges)
+	for i := 0; i < edges; i++ {
+		out = append(out, Edge[int]{
+			From: r.Intn(nodes),
+			To:   r.Intn(nodes),
+			Cost: 1 + r.Intn(20),
+		})
+	}
+	return out
+}
+
+// plain Dijkstra over the edge list, to compare against.
+func shortestDistances(edges []Edge[int], source int) map[int]int {
+	dist := map[int]int{source: 0}
+	settled := map[int]bool{}
+
+	for {
+		u, best := -1, math.MaxInt
+		for v, d := range dist {
+			if !settled[v] && d < best {
+				u, best = v, d
+			}
+		}
+
+		if u == -1 {
+			return dist
+		}
+		settled[u] = true
+
+		for _, e := range edges {
+			if e.From != u {
+				continue
+			}
+			if d, found := dist[e.To]; !found || best+e.Cost < d {
+				dist[e.To] = best + e.Cost
+			}
+		}
+	}
+}
+
+func verifyQueries(t *testing.T, h *Hierarchy[int], edges []Edge[int], nodes int) {
+	t.Helper()
+
+	costs := make(map[pair]int)
+	for _, e := range edges {
+		if c, found := costs[pair{e.From, e.To}]; !found || e.Cost < c {
+			costs[pair{e.From, e.To}] = e.Cost
+		}
+	}
+
+	for source := 0; source < nodes; source += 3 {
+		if _, known := h.index[source]; !known {
+			continue
+		}
+
+		expected := shortestDistances(edges, source)
+
+		for target := 0; target < nodes; target++ {
+			path, cost, err := h.Query(source, target)
+
+			d, reachable := expected[target]
+			if !reachable {
+				if err == nil {
+					t.Errorf("%d -> %d: expected no path but got %v with cost %d", source, target, path, cost)
+				}
+				continue
+			}
+
+			if err != nil {
+				t.Fatalf("%d -> %d: unexpected error %v", source, target, err)
+			}
+
+			if cost != d {
+				t.Errorf("%d -> %d: expected cost %d but got %d", source, target, d, cost)
+			}
+
+			if path[0] != target || path[len(path)-1] != source {
+				t.Errorf("%d -> %d: expected path from target to source, got %v", source, target, path)
+			}
+
+			sum := 0
+			for idx := len(path) - 1; idx > 0; idx-- {
+				c, found := costs[pair{path[idx], path[idx-1]}]
+				if !found {
+					t.Fatalf("%d -> %d: path %v uses unknown edge %d -> %d", source, target, path, path[idx], path[idx-1])
+				}
+				sum += c
+			}
+
+			if sum != cost {
+				t.Errorf("%d -> %d: path %v costs %d, reported %d", source, target, path, sum, cost)
+			}
+		}
+	}
+}
+
+func TestQueryMatchesDijkstra(t *testing.T) {
+	const nodes = 60
+
+	for seed := int64(0); seed < 5; seed++ {
+		edges := randomEdges(nodes, 180, seed)
+		verifyQueries(t, Build(edges), edges, nodes)
+	}
+}
+
+func TestQueryUnknownNode(t *testing.T) {
+	h := Build([]Edge[string]{{From: "a", To: "b", Cost: 1}})
+
+	if _, _, err := h.Query("a", "z"); !errors.Is(err, ErrorUnknownNode) {
+		t.Errorf("expected ErrorUnknownNode but got %v", err)
+	}
+
+	if _, _, err := h.Query("b", "a"); !errors.Is(err, ErrorNoPath) {
+		t.Errorf("expected ErrorNoPath but got %v", err)
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	const nodes = 40
+	edges := randomEdges(nodes, 120, 7)
+
+	var buf bytes.Buffer
+	if err := Build(edges).Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := Load[int](&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verifyQueries(t, h, edges, nodes)
+}
@@ -0,0 +1,159 @@
+package ch
+
+import (
+	"math"
+	"slices"
+
+	"github.com/tmw/pathfind/pkg/prioqueue"
+)
+
+// one direction of a bidirectional query.
+type search struct {
+	arcs   [][]arc
+	dist   map[int]int
+	parent map[int]arc
+	queue  *prioqueue.Prioqueue[int]
+}
+
+func newSearch(arcs [][]arc, source int) *search {
+	s := &search{
+		arcs:   arcs,
+		dist:   map[int]int{source: 0},
+		parent: make(map[int]arc),
+		queue:  prioqueue.New[int](),
+	}
+
+	s.queue.Push(source, 0)
+	return s
+}
+
+// the lowest distance still waiting to be settled.
+func (s *search) next() int {
+	if s.queue.Len() == 0 {
+		return math.MaxInt
+	}
+
+	return s.queue.PriorityOfItem(0)
+}
+
+func (s *search) settle() int {
+	d := s.queue.PriorityOfItem(0)
+	v := s.queue.Pop()
+
+	if d > s.dist[v] {
+		return v
+	}
+
+	for _, a := range s.arcs[v] {
+		if known, found := s.dist[a.To]; !found || d+a.Cost < known {
+			s.dist[a.To] = d + a.Cost
+			s.parent[a.To] = arc{To: v, Cost: a.Cost, Via: a.Via}
+			s.queue.Push(a.To, d+a.Cost)
+		}
+	}
+
+	return v
+}
+
+// returns the shortest path between from and to along with its cost. Like
+// Solver.Walk, the path runs from finish to start.
+func (h *Hierarchy[T]) Query(from, to T) ([]T, int, error) {
+	source, found := h.index[from]
+	if !found {
+		return nil, 0, ErrorUnknownNode
+	}
+
+	target, found := h.index[to]
+	if !found {
+		return nil, 0, ErrorUnknownNode
+	}
+
+	forward := newSearch(h.up, source)
+	backward := newSearch(h.down, target)
+
+	best, meeting := math.MaxInt, -1
+	if source == target {
+		best, meeting = 0, source
+	}
+
+	// both searches only move upwards, so neither can stop at the first
+	// meeting point. They're done once nothing cheaper can be found anymore.
+	for min(forward.next(), backward.next()) < best {
+		s, other := forward, backward
+		if backward.next() < forward.next() {
+			s, other = backward, forward
+		}
+
+		v := s.settle()
+		if d, found := other.dist[v]; found && s.dist[v]+d < best {
+			best, meeting = s.dist[v]+d, v
+		}
+	}
+
+	if meeting == -1 {
+		return nil, 0, ErrorNoPath
+	}
+
+	return h.unpackPath(forward, backward, meeting), best, nil
+}
+
+// collects the original nodes along the path found, from target to source.
+func (h *Hierarchy[T]) unpackPath(forward, backward *search, meeting int) []T {
+	// forward parents point back towards the source.
+	toSource := []int{meeting}
+	for v := meeting; ; {
+		p, found := forward.parent[v]
+		if !found {
+			break
+		}
+		hops := h.unpack(p.To, v, p.Via)
+		slices.Reverse(hops)
+		toSource = append(toSource, hops[1:]...)
+		v = p.To
+	}
+
+	// backward parents point on towards the target.
+	toTarget := []int{}
+	for v := meeting; ; {
+		p, found := backward.parent[v]
+		if !found {
+			break
+		}
+		toTarget = append(toTarget, h.unpack(v, p.To, p.Via)[1:]...)
+		v = p.To
+	}
+
+	path := make([]T, 0, len(toTarget)+len(toSource))
+	for idx := len(toTarget) - 1; idx >= 0; idx-- {
+		path = append(path, h.nodes[toTarget[idx]])
+	}
+
+	for _, v := range toSource {
+		path = append(path, h.nodes[v])
+	}
+
+	return path
+}
+
+// expands the edge from -> to into the original nodes it skips over, in
+// walking order and including both ends.
+func (h *Hierarchy[T]) unpack(from, to, via int) []int {
+	if via == -1 {
+		return []int{from, to}
+	}
+
+	// a shortcut replaces the edges from -> via and via -> to, which were
+	// recorded when via got contracted.
+	first := findArc(h.down[via], from)
+	second := findArc(h.up[via], to)
+
+	return append(h.unpack(from, via, first.Via), h.unpack(via, to, second.Via)[1:]...)
+}
+
+func findArc(arcs []arc, to int) arc {
+	idx := slices.IndexFunc(arcs, func(a arc) bool {
+		return a.To == to
+	})
+
+	return arcs[idx]
+}
@@ -0,0 +1,32 @@
+package ch
+
+import (
+	"encoding/gob"
+	"io"
+)
+
+type serialized[T comparable] struct {
+	Nodes []T
+	Up    [][]arc
+	Down  [][]arc
+}
+
+// writes the preprocessed hierarchy to w, to be read back using Load. Nodes
+// are encoded using encoding/gob, so T must be encodable by it.
+func (h *Hierarchy[T]) Save(w io.Writer) error {
+	return gob.NewEncoder(w).Encode(serialized[T]{
+		Nodes: h.nodes,
+		Up:    h.up,
+		Down:  h.down,
+	})
+}
+
+// reads a hierarchy written by Save.
+func Load[T comparable](r io.Reader) (*Hierarchy[T], error) {
+	var s serialized[T]
+	if err := gob.NewDecoder(r).Decode(&s); err != nil {
+		return nil, err
+	}
+
+	return newHierarchy(s.Nodes, s.Up, s.Down), nil
+}
@@ -0,0 +1,44 @@
+package cooperative
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tmw/pathfind"
+	"github.com/tmw/pathfind/pkg/arena"
+)
+
+var ErrorNoPath = errors.New("cooperative: no path found")
+
+type Agent struct {
+	Start  arena.Coordinate
+	Finish arena.Coordinate
+}
+
+// plans the agents one after the other, in the given order. Each agent's path
+// is reserved before the next agent is planned, so later agents route around
+// (or wait for) the earlier ones. maxTicks bounds the search for every agent.
+// Paths are returned per agent, from finish to start, like Solver.Walk does.
+func Plan(a *arena.Arena, agents []Agent, maxTicks int) ([][]arena.TimedCoordinate, error) {
+	table := arena.NewReservationTable()
+	paths := make([][]arena.TimedCoordinate, 0, len(agents))
+
+	for idx, agent := range agents {
+		s := pathfind.NewSolver[arena.TimedCoordinate](
+			pathfind.AlgorithmAStar,
+			arena.NewTimedCoordinate(agent.Start, 0),
+			arena.NewCooperativeAdapter(a, table, agent.Finish),
+		)
+
+		s.MaxCost = maxTicks
+		path := s.Walk()
+		if len(path) == 0 {
+			return paths, fmt.Errorf("%w for agent %d", ErrorNoPath, idx)
+		}
+
+		table.Reserve(path)
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
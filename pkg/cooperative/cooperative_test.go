@@ -0,0 +1,67 @@
+package cooperative
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tmw/pathfind/pkg/arena"
+)
+
+const input = `
+#########
+#S.....F#
+######.##
+#########
+`
+
+func TestPlan(t *testing.T) {
+	a, err := arena.Parse(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	agents := []Agent{
+		{Start: arena.NewCoordinate(1, 1), Finish: arena.NewCoordinate(7, 1)},
+		{Start: arena.NewCoordinate(7, 1), Finish: arena.NewCoordinate(1, 1)},
+	}
+
+	paths, err := Plan(a, agents, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(paths) != len(agents) {
+		t.Fatalf("expected %d paths, got %d", len(agents), len(paths))
+	}
+
+	occupied := make(map[arena.TimedCoordinate]int)
+	for agent, path := range paths {
+		for _, step := range path {
+			if other, found := occupied[step]; found {
+				t.Errorf("agents %d and %d both occupy %+v", other, agent, step)
+			}
+			occupied[step] = agent
+		}
+	}
+
+	if first, second := len(paths[0]), len(paths[1]); first != 7 || second <= first {
+		t.Errorf("expected the second agent to detour around the first, got path lengths %d and %d", first, second)
+	}
+}
+
+func TestPlanUnsolvable(t *testing.T) {
+	a, err := arena.Parse(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	agents := []Agent{
+		{Start: arena.NewCoordinate(1, 1), Finish: arena.NewCoordinate(3, 1)},
+		{Start: arena.NewCoordinate(7, 1), Finish: arena.NewCoordinate(1, 1)},
+	}
+
+	_, err = Plan(a, agents, 20)
+	if !errors.Is(err, ErrorNoPath) {
+		t.Errorf("expected ErrorNoPath but got %v", err)
+	}
+}
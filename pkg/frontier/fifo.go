@@ -0,0 +1,42 @@
+package frontier
+
+import (
+	"github.com/tmw/pathfind/pkg/prioqueue"
+	"github.com/tmw/pathfind/pkg/queue"
+)
+
+// hands out keys in the order they were first pushed, ignoring priorities.
+// Pushing a key that is queued already replaces its value, but keeps its
+// place in line.
+type FIFO[K comparable, V any, P prioqueue.Number] struct {
+	order  queue.Queue[K]
+	values map[K]V
+}
+
+func NewFIFO[K comparable, V any, P prioqueue.Number]() *FIFO[K, V, P] {
+	return &FIFO[K, V, P]{
+		order:  queue.New[K](),
+		values: make(map[K]V),
+	}
+}
+
+func (f *FIFO[K, V, P]) Push(key K, value V, _ P) {
+	if _, found := f.values[key]; !found {
+		f.order.Push(key)
+	}
+	f.values[key] = value
+}
+
+func (f *FIFO[K, V, P]) Pop() (K, V) {
+	key := f.order.Pop()
+	value := f.values[key]
+	delete(f.values, key)
+	return key, value
+}
+
+func (f *FIFO[K, V, P]) Contains(key K) bool {
+	_, found := f.values[key]
+	return found
+}
+
+func (f *FIFO[K, V, P]) Len() int { return len(f.values) }
@@ -0,0 +1,32 @@
+package frontier
+
+import (
+	"github.com/tmw/pathfind/pkg/prioqueue"
+)
+
+// the set of candidates a search still has to expand, holding at most one
+// value per key. Implementations differ in the order they hand candidates
+// out in and in how they trade off the cost of their operations.
+type Frontier[K comparable, V any, P prioqueue.Number] interface {
+	// queues value under key, or replaces the value and priority when the
+	// key is queued already.
+	Push(key K, value V, prio P)
+
+	// removes and returns the next key, and its value.
+	Pop() (K, V)
+
+	Contains(key K) bool
+	Len() int
+}
+
+// returns a binary heap, which pops the lowest priority first.
+func NewBinary[K comparable, V any, P prioqueue.Number]() Frontier[K, V, P] {
+	return prioqueue.NewKeyedOf[K, V, P]()
+}
+
+// returns a heap in which every node has d children, falling back to 4 when
+// d is less than 2. With more than two the tree is shallower, which pays off
+// when pops dominate.
+func NewDAry[K comparable, V any, P prioqueue.Number](d int) Frontier[K, V, P] {
+	return prioqueue.NewKeyedDAryOf[K, V, P](d)
+}
@@ -0,0 +1,232 @@
+package frontier
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/tmw/pathfind/pkg/prioqueue"
+)
+
+func heaps() map[string]func() Frontier[string, int, int] {
+	return map[string]func() Frontier[string, int, int]{
+		"binary":  NewBinary[string, int, int],
+		"d-ary":   func() Frontier[string, int, int] { return NewDAry[string, int, int](4) },
+		"pairing": func() Frontier[string, int, int] { return NewPairing[string, int, int]() },
+		"radix":   func() Frontier[string, int, int] { return NewRadix[string, int, int]() },
+	}
+}
+
+func popAll[K comparable, V any, P prioqueue.Number](f Frontier[K, V, P]) []K {
+	res := []K{}
+	for f.Len() > 0 {
+		key, _ := f.Pop()
+		res = append(res, key)
+	}
+	return res
+}
+
+func assertEqual[T comparable](t *testing.T, expected, actual []T) {
+	t.Helper()
+	if !slices.Equal(expected, actual) {
+		t.Errorf("expected %v but got %v", expected, actual)
+	}
+}
+
+func TestHeapsPopInOrder(t *testing.T) {
+	for name, newFrontier := range heaps() {
+		newFrontier := newFrontier
+		t.Run(name, func(t *testing.T) {
+			f := newFrontier()
+			f.Push("third", 3, 30)
+			f.Push("first", 1, 10)
+			f.Push("fourth", 4, 40)
+			f.Push("second", 2, 20)
+
+			key, value := f.Pop()
+			if key != "first" || value != 1 {
+				t.Errorf("expected first with value 1 but got %s with value %d", key, value)
+			}
+
+			assertEqual(t, []string{"second", "third", "fourth"}, popAll(f))
+		})
+	}
+}
+
+func TestHeapsPushReplacesExistingKey(t *testing.T) {
+	for name, newFrontier := range heaps() {
+		newFrontier := newFrontier
+		t.Run(name, func(t *testing.T) {
+			f := newFrontier()
+			f.Push("red", 1, 10)
+			f.Push("green", 2, 20)
+			f.Push("orange", 3, 30)
+			f.Push("pink", 4, 40)
+
+			// lower the priority of one key, and raise another.
+			f.Push("orange", 5, 5)
+			f.Push("red", 6, 35)
+
+			if f.Len() != 4 {
+				t.Errorf("expected length of 4, got %d", f.Len())
+			}
+
+			key, value := f.Pop()
+			if key != "orange" || value != 5 {
+				t.Errorf("expected orange with value 5 but got %s with value %d", key, value)
+			}
+
+			assertEqual(t, []string{"green", "red", "pink"}, popAll(f))
+		})
+	}
+}
+
+func TestHeapsContains(t *testing.T) {
+	for name, newFrontier := range heaps() {
+		newFrontier := newFrontier
+		t.Run(name, func(t *testing.T) {
+			f := newFrontier()
+			f.Push("red", 1, 10)
+			f.Push("green", 2, 20)
+
+			if !f.Contains("green") {
+				t.Error("expected green to be queued")
+			}
+
+			f.Pop()
+			if f.Contains("red") {
+				t.Error("expected red to be gone after popping it")
+			}
+		})
+	}
+}
+
+// mimics a search with a consistent heuristic: pushed priorities never drop
+// below the last popped one, and every pop has to hand out a key with the
+// lowest priority queued.
+func TestHeapsPopLowestPriority(t *testing.T) {
+	for name, newFrontier := range heaps() {
+		newFrontier := newFrontier
+		t.Run(name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(1))
+			f := newFrontier()
+			queued := map[string]int{}
+			last := 0
+
+			for step := 0; step < 2000; step++ {
+				if r.Intn(3) > 0 || len(queued) == 0 {
+					key := strconv.Itoa(r.Intn(200))
+					prio := last + r.Intn(50)
+					f.Push(key, prio, prio)
+					queued[key] = prio
+					continue
+				}
+
+				lowest := math.MaxInt
+				for _, prio := range queued {
+					lowest = min(lowest, prio)
+				}
+
+				key, prio := f.Pop()
+				if prio != lowest || queued[key] != prio {
+					t.Fatalf("step %d: expected a key with priority %d but got %s with %d", step, lowest, key, prio)
+				}
+
+				delete(queued, key)
+				last = prio
+
+				if f.Len() != len(queued) {
+					t.Fatalf("step %d: expected length of %d, got %d", step, len(queued), f.Len())
+				}
+			}
+		})
+	}
+}
+
+func TestHeapsFloatPriorities(t *testing.T) {
+	for name, f := range map[string]Frontier[string, int, float64]{
+		"binary":  NewBinary[string, int, float64](),
+		"d-ary":   NewDAry[string, int, float64](4),
+		"pairing": NewPairing[string, int, float64](),
+	} {
+		f.Push("red", 1, 1.5)
+		f.Push("green", 2, 1.25)
+		f.Push("orange", 3, 1.75)
+		f.Push("orange", 3, 0.5)
+
+		if actual := popAll(f); !slices.Equal(actual, []string{"orange", "green", "red"}) {
+			t.Errorf("%s: expected orange, green and red but got %v", name, actual)
+		}
+	}
+}
+
+func TestRadixClampsLowPriorities(t *testing.T) {
+	f := NewRadix[string, int, int]()
+	f.Push("red", 1, 10)
+	f.Push("green", 2, 20)
+	f.Pop()
+
+	f.Push("orange", 3, 5)
+	f.Push("pink", 4, 15)
+
+	assertEqual(t, []string{"orange", "pink", "green"}, popAll[string, int, int](f))
+}
+
+func TestFIFOIgnoresPriorities(t *testing.T) {
+	f := NewFIFO[string, int, int]()
+	f.Push("first", 1, 30)
+	f.Push("second", 2, 10)
+	f.Push("third", 3, 20)
+	f.Push("first", 4, 0)
+
+	if f.Len() != 3 {
+		t.Errorf("expected length of 3, got %d", f.Len())
+	}
+
+	key, value := f.Pop()
+	if key != "first" || value != 4 {
+		t.Errorf("expected first with value 4 but got %s with value %d", key, value)
+	}
+
+	assertEqual(t, []string{"second", "third"}, popAll[string, int, int](f))
+}
+
+// pushes a grid worth of keys and lowers the priority of most of them
+// before draining the frontier, like a search on a large open map.
+func BenchmarkFrontiers(b *testing.B) {
+	const size = 20000
+	r := rand.New(rand.NewSource(1))
+	prios := make([]int, size)
+	for idx := range prios {
+		prios[idx] = size + r.Intn(size)
+	}
+
+	for name, newFrontier := range map[string]func() Frontier[int, int, int]{
+		"binary":  NewBinary[int, int, int],
+		"d-ary":   func() Frontier[int, int, int] { return NewDAry[int, int, int](4) },
+		"pairing": func() Frontier[int, int, int] { return NewPairing[int, int, int]() },
+		"radix":   func() Frontier[int, int, int] { return NewRadix[int, int, int]() },
+	} {
+		newFrontier := newFrontier
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				f := newFrontier()
+				for k, prio := range prios {
+					f.Push(k, k, prio)
+				}
+
+				for k, prio := range prios {
+					if k%4 != 0 {
+						f.Push(k, k, prio-size/2)
+					}
+				}
+
+				for f.Len() > 0 {
+					f.Pop()
+				}
+			}
+		})
+	}
+}
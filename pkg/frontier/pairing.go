@@ -0,0 +1,149 @@
+package frontier
+
+import (
+	"github.com/tmw/pathfind/pkg/prioqueue"
+)
+
+// a pairing heap, a tree where decreasing a priority is a matter of cutting
+// a subtree loose and melding it back with the root. Suits searches that
+// find cheaper routes to queued candidates often.
+type Pairing[K comparable, V any, P prioqueue.Number] struct {
+	root  *pairingNode[K, V, P]
+	nodes map[K]*pairingNode[K, V, P]
+}
+
+type pairingNode[K comparable, V any, P prioqueue.Number] struct {
+	key   K
+	value V
+	prio  P
+
+	child   *pairingNode[K, V, P]
+	sibling *pairingNode[K, V, P]
+
+	// the parent for the leftmost child, the left sibling otherwise.
+	prev *pairingNode[K, V, P]
+}
+
+func NewPairing[K comparable, V any, P prioqueue.Number]() *Pairing[K, V, P] {
+	return &Pairing[K, V, P]{
+		nodes: make(map[K]*pairingNode[K, V, P]),
+	}
+}
+
+func (h *Pairing[K, V, P]) Push(key K, value V, prio P) {
+	n, found := h.nodes[key]
+	if !found {
+		n = &pairingNode[K, V, P]{key: key, value: value, prio: prio}
+		h.nodes[key] = n
+		h.root = meld(h.root, n)
+		return
+	}
+
+	n.value = value
+	if prio < n.prio {
+		n.prio = prio
+		if n != h.root {
+			cut(n)
+			h.root = meld(h.root, n)
+		}
+		return
+	}
+
+	// a higher priority may push the node below its children, so take it
+	// out and put it back in.
+	h.remove(n)
+	n.prio = prio
+	h.root = meld(h.root, n)
+}
+
+func (h *Pairing[K, V, P]) Pop() (K, V) {
+	n := h.root
+	h.remove(n)
+	delete(h.nodes, n.key)
+	return n.key, n.value
+}
+
+func (h *Pairing[K, V, P]) Contains(key K) bool {
+	_, found := h.nodes[key]
+	return found
+}
+
+func (h *Pairing[K, V, P]) Len() int { return len(h.nodes) }
+
+// takes n out of the tree, leaving it without children.
+func (h *Pairing[K, V, P]) remove(n *pairingNode[K, V, P]) {
+	children := mergePairs(n.child)
+	n.child = nil
+
+	if n == h.root {
+		h.root = children
+		return
+	}
+
+	cut(n)
+	h.root = meld(h.root, children)
+}
+
+// detaches n, and its subtree, from its parent and siblings.
+func cut[K comparable, V any, P prioqueue.Number](n *pairingNode[K, V, P]) {
+	if n.prev.child == n {
+		n.prev.child = n.sibling
+	} else {
+		n.prev.sibling = n.sibling
+	}
+
+	if n.sibling != nil {
+		n.sibling.prev = n.prev
+	}
+
+	n.prev, n.sibling = nil, nil
+}
+
+// joins two trees, making the root with the higher priority the leftmost
+// child of the other.
+func meld[K comparable, V any, P prioqueue.Number](a, b *pairingNode[K, V, P]) *pairingNode[K, V, P] {
+	if a == nil {
+		return b
+	}
+
+	if b == nil {
+		return a
+	}
+
+	if b.prio < a.prio {
+		a, b = b, a
+	}
+
+	b.prev = a
+	b.sibling = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+
+	return a
+}
+
+// melds a list of siblings into one tree, pairing them up left to right
+// and then folding the pairs right to left.
+func mergePairs[K comparable, V any, P prioqueue.Number](first *pairingNode[K, V, P]) *pairingNode[K, V, P] {
+	pairs := []*pairingNode[K, V, P]{}
+	for first != nil {
+		a, b := first, first.sibling
+		first = nil
+		if b != nil {
+			first = b.sibling
+			b.prev, b.sibling = nil, nil
+		}
+		a.prev, a.sibling = nil, nil
+
+		pairs = append(pairs, meld(a, b))
+	}
+
+	var root *pairingNode[K, V, P]
+	for idx := len(pairs) - 1; idx >= 0; idx-- {
+		root = meld(pairs[idx], root)
+	}
+
+	return root
+}
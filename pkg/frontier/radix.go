@@ -0,0 +1,100 @@
+package frontier
+
+import (
+	"math/bits"
+)
+
+// the priority types a radix heap can bucket by.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// a radix heap, bucketing candidates by the highest bit in which their
+// priority differs from the last popped one. Only works for priorities that
+// never drop below the last popped priority, as with A* and a consistent
+// heuristic. Lower priorities are clamped to the last popped priority.
+type Radix[K comparable, V any, P Integer] struct {
+	buckets [bits.UintSize + 1][]radixEntry[K, P]
+	items   map[K]radixItem[V]
+	last    P
+
+	// tells the current entry of a key apart from the stale ones left
+	// behind when it was pushed again.
+	seq uint64
+}
+
+type radixEntry[K comparable, P Integer] struct {
+	key  K
+	prio P
+	seq  uint64
+}
+
+type radixItem[V any] struct {
+	value V
+	seq   uint64
+}
+
+func NewRadix[K comparable, V any, P Integer]() *Radix[K, V, P] {
+	return &Radix[K, V, P]{
+		items: make(map[K]radixItem[V]),
+	}
+}
+
+func (h *Radix[K, V, P]) Push(key K, value V, prio P) {
+	prio = max(prio, h.last)
+
+	h.seq++
+	h.items[key] = radixItem[V]{value: value, seq: h.seq}
+	h.add(radixEntry[K, P]{key: key, prio: prio, seq: h.seq})
+}
+
+func (h *Radix[K, V, P]) Pop() (K, V) {
+	for {
+		if len(h.buckets[0]) == 0 {
+			h.redistribute()
+		}
+
+		last := len(h.buckets[0]) - 1
+		e := h.buckets[0][last]
+		h.buckets[0] = h.buckets[0][:last]
+
+		if item, found := h.items[e.key]; found && item.seq == e.seq {
+			delete(h.items, e.key)
+			return e.key, item.value
+		}
+	}
+}
+
+func (h *Radix[K, V, P]) Contains(key K) bool {
+	_, found := h.items[key]
+	return found
+}
+
+func (h *Radix[K, V, P]) Len() int { return len(h.items) }
+
+func (h *Radix[K, V, P]) add(e radixEntry[K, P]) {
+	b := bits.Len(uint(e.prio ^ h.last))
+	h.buckets[b] = append(h.buckets[b], e)
+}
+
+// moves the entries of the first non-empty bucket over into lower buckets,
+// relative to the lowest priority among them, which ends up in bucket 0.
+func (h *Radix[K, V, P]) redistribute() {
+	b := 1
+	for len(h.buckets[b]) == 0 {
+		b++
+	}
+
+	entries := h.buckets[b]
+	h.buckets[b] = nil
+
+	h.last = entries[0].prio
+	for _, e := range entries[1:] {
+		h.last = min(h.last, e.prio)
+	}
+
+	for _, e := range entries {
+		h.add(e)
+	}
+}
@@ -0,0 +1,93 @@
+package graph
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var ErrorInvalidCSV = errors.New("graph: invalid csv")
+
+// reads an edge list with one from,to,cost edge per line. A first line
+// reading from,to,cost is taken as header and skipped. Nodes without edges
+// are listed as a line of their own, leaving to and cost empty.
+func ReadCSV(r io.Reader, direction Direction) (*Graph[string], error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	g := New[string](direction)
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return g, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrorInvalidCSV, err)
+		}
+
+		if first && isCSVHeader(record) {
+			continue
+		}
+
+		// quoted fields may span lines, so records don't map to lines
+		line, _ := reader.FieldPos(0)
+
+		if strings.TrimSpace(record[1]) == "" && strings.TrimSpace(record[2]) == "" {
+			if strings.TrimSpace(record[0]) == "" {
+				return nil, fmt.Errorf("%w: line %d: node is empty", ErrorInvalidCSV, line)
+			}
+
+			g.AddNode(strings.TrimSpace(record[0]))
+			continue
+		}
+
+		cost, err := strconv.Atoi(strings.TrimSpace(record[2]))
+		if err != nil {
+			costLine, _ := reader.FieldPos(2)
+			return nil, fmt.Errorf("%w: line %d: cost %q is not a number", ErrorInvalidCSV, costLine, record[2])
+		}
+
+		if err := g.AddEdge(strings.TrimSpace(record[0]), strings.TrimSpace(record[1]), cost); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrorInvalidCSV, line, err)
+		}
+	}
+}
+
+// writes the edges of the graph as from,to,cost lines, below a header,
+// followed by the nodes without edges in the way ReadCSV reads them.
+func WriteCSV(w io.Writer, g *Graph[string]) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"from", "to", "cost"}); err != nil {
+		return err
+	}
+
+	for _, e := range g.Edges() {
+		if err := writer.Write([]string{e.From, e.To, strconv.Itoa(e.Cost)}); err != nil {
+			return err
+		}
+	}
+
+	for _, n := range g.Nodes() {
+		if len(g.Neighbours(n)) > 0 || len(g.Predecessors(n)) > 0 {
+			continue
+		}
+
+		if err := writer.Write([]string{n, "", ""}); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func isCSVHeader(record []string) bool {
+	return strings.EqualFold(strings.TrimSpace(record[0]), "from") &&
+		strings.EqualFold(strings.TrimSpace(record[1]), "to") &&
+		strings.EqualFold(strings.TrimSpace(record[2]), "cost")
+}
@@ -0,0 +1,75 @@
+package graph
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReadCSV(t *testing.T) {
+	input := "from,to,cost\n# comments are skipped\na,b,3\nb, c, 2\n\"New York\",a,1\n"
+
+	g, err := ReadCSV(strings.NewReader(input), Directed)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, e := range []Edge[string]{
+		{From: "a", To: "b", Cost: 3},
+		{From: "b", To: "c", Cost: 2},
+		{From: "New York", To: "a", Cost: 1},
+	} {
+		if !g.HasEdge(e.From, e.To) || g.EdgeCost(e.From, e.To) != e.Cost {
+			t.Errorf("expected edge %s -> %s costing %d", e.From, e.To, e.Cost)
+		}
+	}
+
+	if g.HasNode("from") {
+		t.Error("expected the header not to be read as an edge")
+	}
+}
+
+func TestReadCSVErrors(t *testing.T) {
+	for _, input := range []string{
+		"a,b\n",
+		"a,b,3,4\n",
+		"a,b,three\n",
+		"a,b,-3\n",
+		",,\n",
+	} {
+		if _, err := ReadCSV(strings.NewReader(input), Directed); !errors.Is(err, ErrorInvalidCSV) {
+			t.Errorf("expected ErrorInvalidCSV for %q, got %v", input, err)
+		}
+	}
+}
+
+func TestReadCSVErrorLine(t *testing.T) {
+	input := "from,to,cost\n# comments are skipped\n\"New\nYork\",a,1\na,b,three\n"
+
+	_, err := ReadCSV(strings.NewReader(input), Directed)
+	if err == nil || !strings.Contains(err.Error(), "line 5:") {
+		t.Errorf("expected an error on line 5, got %v", err)
+	}
+}
+
+func TestWriteCSVRoundTrip(t *testing.T) {
+	for _, direction := range []Direction{Directed, Undirected} {
+		g := New[string](direction)
+		g.AddNode("lonely")
+		g.AddEdge("a", "b", 3)
+		g.AddEdge("b", "New, York", 0)
+
+		var b bytes.Buffer
+		if err := WriteCSV(&b, g); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		read, err := ReadCSV(&b, direction)
+		if err != nil {
+			t.Fatalf("expected written csv to be readable, got %v", err)
+		}
+
+		assertSameGraph(t, g, read)
+	}
+}
@@ -0,0 +1,435 @@
+package graph
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var ErrorInvalidDOT = errors.New("graph: invalid dot")
+
+// reads a graph in the Graphviz DOT language. Only the subset needed to
+// describe a plain graph is supported: node and edge statements, including
+// chains like a -> b -> c. Edges cost their weight or cost attribute, or 1
+// when neither is set. Other attributes, attribute statements and ports are
+// ignored; subgraphs are not supported.
+func ReadDOT(r io.Reader) (*Graph[string], error) {
+	contents, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := tokenizeDOT(string(contents))
+	if err != nil {
+		return nil, err
+	}
+
+	p := &dotParser{tokens: tokens}
+	g, err := p.parse()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrorInvalidDOT, err)
+	}
+
+	return g, nil
+}
+
+// writes the graph in the Graphviz DOT language, with costs as the weight
+// attribute of the edges. Nodes without edges are listed on their own.
+func WriteDOT(w io.Writer, g *Graph[string]) error {
+	b := bufio.NewWriter(w)
+
+	keyword, op := "graph", "--"
+	if g.Direction() == Directed {
+		keyword, op = "digraph", "->"
+	}
+
+	fmt.Fprintf(b, "%s {\n", keyword)
+
+	connected := make(map[string]bool)
+	edges := g.Edges()
+	for _, e := range edges {
+		connected[e.From], connected[e.To] = true, true
+	}
+
+	for _, n := range g.Nodes() {
+		if !connected[n] {
+			fmt.Fprintf(b, "  %s;\n", quoteDOT(n))
+		}
+	}
+
+	for _, e := range edges {
+		fmt.Fprintf(b, "  %s %s %s [weight=%d];\n", quoteDOT(e.From), op, quoteDOT(e.To), e.Cost)
+	}
+
+	fmt.Fprint(b, "}\n")
+	return b.Flush()
+}
+
+// returns the id as is when DOT allows it unquoted, and quoted otherwise.
+func quoteDOT(id string) string {
+	if isDOTIdentifier(id) && !isDOTKeyword(id) {
+		return id
+	}
+
+	return `"` + strings.ReplaceAll(id, `"`, `\"`) + `"`
+}
+
+func isDOTIdentifier(id string) bool {
+	if id == "" {
+		return false
+	}
+
+	for idx, r := range id {
+		if r != '_' && !unicode.IsLetter(r) && (idx == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDOTKeyword(id string) bool {
+	switch strings.ToLower(id) {
+	case "strict", "graph", "digraph", "node", "edge", "subgraph":
+		return true
+	default:
+		return false
+	}
+}
+
+type dotTokenKind int
+
+const (
+	dotID dotTokenKind = iota
+	dotSymbol
+)
+
+type dotToken struct {
+	kind  dotTokenKind
+	value string
+	line  int
+
+	// quoted ids are never keywords
+	quoted bool
+}
+
+func (t dotToken) isSymbol(symbol string) bool {
+	return t.kind == dotSymbol && t.value == symbol
+}
+
+func (t dotToken) isKeyword(keyword string) bool {
+	return t.kind == dotID && !t.quoted && strings.EqualFold(t.value, keyword)
+}
+
+// reports whether r can be part of an unquoted id or number. A minus sign
+// is only allowed first, to start a negative number.
+func isDOTIDRune(r rune, first bool) bool {
+	return r == '_' || r == '.' || unicode.IsLetter(r) || unicode.IsDigit(r) || (first && r == '-')
+}
+
+// splits the input into identifiers and symbols, dropping whitespace and
+// comments. Quoted identifiers are returned without their quotes.
+func tokenizeDOT(input string) ([]dotToken, error) {
+	tokens := []dotToken{}
+	runes := []rune(input)
+	line := 1
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case r == '\n':
+			line++
+			i++
+
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '#' || (r == '/' && i+1 < len(runes) && runes[i+1] == '/'):
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			start := line
+			for i += 2; i+1 < len(runes) && (runes[i] != '*' || runes[i+1] != '/'); i++ {
+				if runes[i] == '\n' {
+					line++
+				}
+			}
+			if i+1 >= len(runes) {
+				return nil, fmt.Errorf("%w: line %d: unterminated comment", ErrorInvalidDOT, start)
+			}
+			i += 2
+
+		case r == '-' && i+1 < len(runes) && (runes[i+1] == '>' || runes[i+1] == '-'):
+			tokens = append(tokens, dotToken{kind: dotSymbol, value: string(runes[i : i+2]), line: line})
+			i += 2
+
+		case strings.ContainsRune("{}[];,=:", r):
+			tokens = append(tokens, dotToken{kind: dotSymbol, value: string(r), line: line})
+			i++
+
+		case r == '"':
+			var value strings.Builder
+			start := line
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) && runes[i+1] == '"' {
+					i++
+				}
+				if runes[i] == '\n' {
+					line++
+				}
+				value.WriteRune(runes[i])
+			}
+			if i == len(runes) {
+				return nil, fmt.Errorf("%w: line %d: unterminated string", ErrorInvalidDOT, start)
+			}
+			tokens = append(tokens, dotToken{kind: dotID, value: value.String(), line: start, quoted: true})
+			i++
+
+		case isDOTIDRune(r, true):
+			start := i
+			for i < len(runes) && isDOTIDRune(runes[i], i == start) {
+				i++
+			}
+			tokens = append(tokens, dotToken{kind: dotID, value: string(runes[start:i]), line: line})
+
+		default:
+			return nil, fmt.Errorf("%w: line %d: unexpected %q", ErrorInvalidDOT, line, r)
+		}
+	}
+
+	return tokens, nil
+}
+
+type dotParser struct {
+	tokens []dotToken
+	pos    int
+	graph  *Graph[string]
+	edgeOp string
+}
+
+func (p *dotParser) peek() (dotToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return dotToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+// reports whether the next token is the given symbol, consuming it if so.
+func (p *dotParser) accept(symbol string) bool {
+	t, ok := p.peek()
+	if ok && t.isSymbol(symbol) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// reports whether the next token is the given keyword, consuming it if so.
+func (p *dotParser) acceptKeyword(keyword string) bool {
+	t, ok := p.peek()
+	if ok && t.isKeyword(keyword) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *dotParser) expect(symbol string) error {
+	if !p.accept(symbol) {
+		return p.unexpected(symbol)
+	}
+	return nil
+}
+
+func (p *dotParser) id() (string, error) {
+	t, ok := p.peek()
+	if !ok || t.kind != dotID {
+		return "", p.unexpected("identifier")
+	}
+	p.pos++
+	return t.value, nil
+}
+
+func (p *dotParser) unexpected(expected string) error {
+	t, ok := p.peek()
+	if !ok {
+		return fmt.Errorf("expected %s but reached the end", expected)
+	}
+	return fmt.Errorf("line %d: expected %s but got %q", t.line, expected, t.value)
+}
+
+func (p *dotParser) parse() (*Graph[string], error) {
+	p.acceptKeyword("strict")
+
+	switch {
+	case p.acceptKeyword("digraph"):
+		p.graph, p.edgeOp = New[string](Directed), "->"
+
+	case p.acceptKeyword("graph"):
+		p.graph, p.edgeOp = New[string](Undirected), "--"
+
+	default:
+		return nil, p.unexpected("graph or digraph")
+	}
+
+	// the name of the graph is optional, and not kept
+	if t, ok := p.peek(); ok && t.kind == dotID {
+		p.pos++
+	}
+
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	for !p.accept("}") {
+		if err := p.statement(); err != nil {
+			return nil, err
+		}
+		p.accept(";")
+	}
+
+	if t, ok := p.peek(); ok {
+		return nil, fmt.Errorf("line %d: unexpected %q after the graph", t.line, t.value)
+	}
+
+	return p.graph, nil
+}
+
+func (p *dotParser) statement() error {
+	t, ok := p.peek()
+	if !ok {
+		return p.unexpected("statement or }")
+	}
+
+	if t.isKeyword("subgraph") || t.isSymbol("{") {
+		return fmt.Errorf("line %d: subgraphs are not supported", t.line)
+	}
+
+	// attribute statements only affect rendering
+	if p.acceptKeyword("graph") || p.acceptKeyword("node") || p.acceptKeyword("edge") {
+		_, err := p.attributes()
+		return err
+	}
+
+	first, err := p.nodeID()
+	if err != nil {
+		return err
+	}
+
+	// a graph attribute like rankdir=LR
+	if p.accept("=") {
+		_, err := p.id()
+		return err
+	}
+
+	nodes := []string{first}
+	for {
+		t, ok := p.peek()
+		if !ok || t.kind != dotSymbol || (t.value != "->" && t.value != "--") {
+			break
+		}
+
+		if t.value != p.edgeOp {
+			return fmt.Errorf("line %d: edge %s used in a graph expecting %s", t.line, t.value, p.edgeOp)
+		}
+		p.pos++
+
+		n, err := p.nodeID()
+		if err != nil {
+			return err
+		}
+		nodes = append(nodes, n)
+	}
+
+	attributes, err := p.attributes()
+	if err != nil {
+		return err
+	}
+
+	if len(nodes) == 1 {
+		p.graph.AddNode(first)
+		return nil
+	}
+
+	cost, err := dotCost(attributes, t.line)
+	if err != nil {
+		return err
+	}
+
+	for idx := 1; idx < len(nodes); idx++ {
+		if err := p.graph.AddEdge(nodes[idx-1], nodes[idx], cost); err != nil {
+			return fmt.Errorf("line %d: %w", t.line, err)
+		}
+	}
+
+	return nil
+}
+
+// reads a node id, skipping the port it may be followed by.
+func (p *dotParser) nodeID() (string, error) {
+	id, err := p.id()
+	if err != nil {
+		return "", err
+	}
+
+	for p.accept(":") {
+		if _, err := p.id(); err != nil {
+			return "", err
+		}
+	}
+
+	return id, nil
+}
+
+// reads any number of bracketed attribute lists into a single map.
+func (p *dotParser) attributes() (map[string]string, error) {
+	attributes := make(map[string]string)
+	for p.accept("[") {
+		for !p.accept("]") {
+			key, err := p.id()
+			if err != nil {
+				return nil, err
+			}
+
+			if err := p.expect("="); err != nil {
+				return nil, err
+			}
+
+			value, err := p.id()
+			if err != nil {
+				return nil, err
+			}
+
+			attributes[strings.ToLower(key)] = value
+			if !p.accept(",") {
+				p.accept(";")
+			}
+		}
+	}
+	return attributes, nil
+}
+
+// returns the cost of an edge from its weight or cost attribute.
+func dotCost(attributes map[string]string, line int) (int, error) {
+	for _, key := range []string{"weight", "cost"} {
+		value, found := attributes[key]
+		if !found {
+			continue
+		}
+
+		cost, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, fmt.Errorf("line %d: %s %q is not a number", line, key, value)
+		}
+		return cost, nil
+	}
+
+	return 1, nil
+}
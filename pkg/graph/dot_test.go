@@ -0,0 +1,136 @@
+package graph
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestReadDOT(t *testing.T) {
+	input := `
+		// road network
+		strict digraph roads {
+			rankdir=LR;
+			node [shape=circle];
+			"New York";
+			a -> b [weight=3];
+			b -> c -> d [label="x", cost=2]
+			/* ports are ignored */
+			d:n -> a:s
+			# so are attributes other than weight and cost
+			c -> "New York" [color=red]
+		}
+	`
+
+	g, err := ReadDOT(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if g.Direction() != Directed {
+		t.Error("expected a digraph to be read as directed")
+	}
+
+	if !slices.Equal(g.Nodes(), []string{"New York", "a", "b", "c", "d"}) {
+		t.Errorf("expected nodes in order of appearance, got %v", g.Nodes())
+	}
+
+	for _, e := range []Edge[string]{
+		{From: "a", To: "b", Cost: 3},
+		{From: "b", To: "c", Cost: 2},
+		{From: "c", To: "d", Cost: 2},
+		{From: "d", To: "a", Cost: 1},
+		{From: "c", To: "New York", Cost: 1},
+	} {
+		if !g.HasEdge(e.From, e.To) || g.EdgeCost(e.From, e.To) != e.Cost {
+			t.Errorf("expected edge %s -> %s costing %d", e.From, e.To, e.Cost)
+		}
+	}
+
+	if len(g.Edges()) != 5 {
+		t.Errorf("expected 5 edges, got %v", g.Edges())
+	}
+}
+
+func TestReadDOTUndirected(t *testing.T) {
+	g, err := ReadDOT(strings.NewReader("graph { a -- b [weight=4] }"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if g.Direction() != Undirected || g.EdgeCost("b", "a") != 4 {
+		t.Errorf("expected an undirected edge b -- a costing 4, got %v", g.Edges())
+	}
+}
+
+func TestReadDOTErrors(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"digraph { a -- b }",
+		"graph { a -> b }",
+		"digraph { a -> b [weight=heavy] }",
+		"digraph { a -> b [weight=-1] }",
+		"digraph { subgraph s { a } }",
+		"digraph { a -> b",
+		`digraph { "a -> b }`,
+		"digraph { a } b",
+	} {
+		if _, err := ReadDOT(strings.NewReader(input)); !errors.Is(err, ErrorInvalidDOT) {
+			t.Errorf("expected ErrorInvalidDOT for %q, got %v", input, err)
+		}
+	}
+}
+
+func TestWriteDOTRoundTrip(t *testing.T) {
+	for _, direction := range []Direction{Directed, Undirected} {
+		g := New[string](direction)
+		g.AddNode("lonely")
+		g.AddEdge("a", "b", 3)
+		g.AddEdge("b", "New York", 0)
+		g.AddEdge("node", "a", 7)
+
+		var b bytes.Buffer
+		if err := WriteDOT(&b, g); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		read, err := ReadDOT(&b)
+		if err != nil {
+			t.Fatalf("expected written dot to be readable, got %v", err)
+		}
+
+		assertSameGraph(t, g, read)
+	}
+}
+
+// checks whether both graphs have the same direction, nodes and edges,
+// regardless of order.
+func assertSameGraph(t *testing.T, expected, actual *Graph[string]) {
+	t.Helper()
+
+	if expected.Direction() != actual.Direction() {
+		t.Errorf("expected direction %v, got %v", expected.Direction(), actual.Direction())
+	}
+
+	nodes := func(g *Graph[string]) []string {
+		n := g.Nodes()
+		slices.Sort(n)
+		return n
+	}
+
+	if !slices.Equal(nodes(expected), nodes(actual)) {
+		t.Errorf("expected nodes %v, got %v", nodes(expected), nodes(actual))
+	}
+
+	if len(expected.Edges()) != len(actual.Edges()) {
+		t.Errorf("expected edges %v, got %v", expected.Edges(), actual.Edges())
+	}
+
+	for _, e := range expected.Edges() {
+		if !actual.HasEdge(e.From, e.To) || actual.EdgeCost(e.From, e.To) != e.Cost {
+			t.Errorf("expected edge %v, got edges %v", e, actual.Edges())
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Package graph holds an in-memory weighted graph of explicit nodes and
+// edges, for data that isn't shaped like a grid. Graphs work as adapters for
+// the pathfind solvers through Target, which picks the finish per query.
+package graph
+
+import (
+	"errors"
+	"slices"
+)
+
+var ErrorNegativeCost = errors.New("graph: edge cost must not be negative")
+
+type Direction int
+
+const (
+	Directed Direction = iota
+
+	// every edge can be walked both ways, at the same cost.
+	Undirected
+)
+
+// estimates the cost of moving between two nodes. To keep searches optimal,
+// it must never estimate more than the actual cost.
+type Heuristic[T comparable] func(from, to T) int
+
+type Edge[T comparable] struct {
+	From T
+	To   T
+	Cost int
+}
+
+// a directed edge, for looking up its cost.
+type edge[T comparable] struct {
+	from, to T
+}
+
+// a weighted graph. Nodes and edges are kept in the order they were added,
+// so searches over the same graph always break ties the same way. Edge costs
+// are kept apart from that order, so looking one up doesn't depend on the
+// number of edges of a node.
+type Graph[T comparable] struct {
+	direction Direction
+	nodes     []T
+	out       map[T][]T
+	in        map[T][]T
+	costs     map[edge[T]]int
+
+	// estimates the cost between nodes for searches towards a Target.
+	// Without it, searches run without a heuristic, like Dijkstra.
+	Heuristic Heuristic[T]
+}
+
+func New[T comparable](direction Direction) *Graph[T] {
+	return &Graph[T]{
+		direction: direction,
+		out:       make(map[T][]T),
+		in:        make(map[T][]T),
+		costs:     make(map[edge[T]]int),
+	}
+}
+
+func (g *Graph[T]) Direction() Direction {
+	return g.direction
+}
+
+// adds a node without edges. Adding a node twice has no effect.
+func (g *Graph[T]) AddNode(n T) {
+	if g.HasNode(n) {
+		return
+	}
+
+	g.nodes = append(g.nodes, n)
+	g.out[n] = []T{}
+	g.in[n] = []T{}
+}
+
+func (g *Graph[T]) HasNode(n T) bool {
+	_, found := g.out[n]
+	return found
+}
+
+// returns the nodes in the order they were added.
+func (g *Graph[T]) Nodes() []T {
+	return slices.Clone(g.nodes)
+}
+
+// adds an edge from -> to, adding both nodes when they're new. Adding an
+// edge that exists already updates its cost. For undirected graphs the edge
+// to -> from is added as well.
+func (g *Graph[T]) AddEdge(from, to T, cost int) error {
+	if cost < 0 {
+		return ErrorNegativeCost
+	}
+
+	g.AddNode(from)
+	g.AddNode(to)
+	g.setEdge(from, to, cost)
+
+	if g.direction == Undirected && from != to {
+		g.setEdge(to, from, cost)
+	}
+
+	return nil
+}
+
+// removes the edge from -> to, and for undirected graphs to -> from as
+// well. Returns false when there is no such edge.
+func (g *Graph[T]) RemoveEdge(from, to T) bool {
+	if !g.HasEdge(from, to) {
+		return false
+	}
+
+	g.deleteEdge(from, to)
+	if g.direction == Undirected {
+		g.deleteEdge(to, from)
+	}
+
+	return true
+}
+
+func (g *Graph[T]) HasEdge(from, to T) bool {
+	_, found := g.costs[edge[T]{from: from, to: to}]
+	return found
+}
+
+// returns all edges, in the order they were added. Undirected edges are
+// returned once, in the direction they were first added.
+func (g *Graph[T]) Edges() []Edge[T] {
+	edges := []Edge[T]{}
+	seen := make(map[edge[T]]struct{})
+
+	for _, from := range g.nodes {
+		for _, to := range g.out[from] {
+			if g.direction == Undirected {
+				if _, found := seen[edge[T]{from: to, to: from}]; found {
+					continue
+				}
+				seen[edge[T]{from: from, to: to}] = struct{}{}
+			}
+			edges = append(edges, Edge[T]{From: from, To: to, Cost: g.EdgeCost(from, to)})
+		}
+	}
+
+	return edges
+}
+
+// returns the nodes reachable from n over a single edge.
+func (g *Graph[T]) Neighbours(n T) []T {
+	return slices.Clone(g.out[n])
+}
+
+// returns the nodes from which n is reachable over a single edge.
+func (g *Graph[T]) Predecessors(n T) []T {
+	return slices.Clone(g.in[n])
+}
+
+// returns the cost of the edge from -> to, which is expected to exist.
+func (g *Graph[T]) EdgeCost(from, to T) int {
+	return g.costs[edge[T]{from: from, to: to}]
+}
+
+// estimates the cost of moving between two nodes using Heuristic, or 0
+// when it isn't set.
+func (g *Graph[T]) Estimate(from, to T) int {
+	if g.Heuristic == nil {
+		return 0
+	}
+	return g.Heuristic(from, to)
+}
+
+func (g *Graph[T]) setEdge(from, to T, cost int) {
+	if !g.HasEdge(from, to) {
+		g.out[from] = append(g.out[from], to)
+		g.in[to] = append(g.in[to], from)
+	}
+
+	g.costs[edge[T]{from: from, to: to}] = cost
+}
+
+func (g *Graph[T]) deleteEdge(from, to T) {
+	delete(g.costs, edge[T]{from: from, to: to})
+	g.out[from] = slices.DeleteFunc(g.out[from], func(n T) bool { return n == to })
+	g.in[to] = slices.DeleteFunc(g.in[to], func(n T) bool { return n == from })
+}
@@ -0,0 +1,142 @@
+package graph
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/tmw/pathfind"
+)
+
+var (
+	_ pathfind.WeightedAdapter[string]   = &Target[string]{}
+	_ pathfind.ReversibleAdapter[string] = &Target[string]{}
+	_ pathfind.Estimator[string]         = &Target[string]{}
+)
+
+func TestAddEdge(t *testing.T) {
+	g := New[string](Directed)
+	g.AddEdge("a", "b", 3)
+	g.AddEdge("a", "c", 1)
+	g.AddEdge("a", "b", 2)
+
+	if !slices.Equal(g.Nodes(), []string{"a", "b", "c"}) {
+		t.Errorf("expected nodes a, b and c, got %v", g.Nodes())
+	}
+
+	if !slices.Equal(g.Neighbours("a"), []string{"b", "c"}) {
+		t.Errorf("expected neighbours b and c, got %v", g.Neighbours("a"))
+	}
+
+	if g.EdgeCost("a", "b") != 2 {
+		t.Errorf("expected the cost of a -> b to be updated to 2, got %d", g.EdgeCost("a", "b"))
+	}
+
+	if g.HasEdge("b", "a") {
+		t.Error("expected no edge b -> a in a directed graph")
+	}
+
+	if !slices.Equal(g.Predecessors("b"), []string{"a"}) {
+		t.Errorf("expected a as predecessor of b, got %v", g.Predecessors("b"))
+	}
+
+	if err := g.AddEdge("a", "d", -1); !errors.Is(err, ErrorNegativeCost) {
+		t.Errorf("expected ErrorNegativeCost but got %v", err)
+	}
+}
+
+func TestUndirected(t *testing.T) {
+	g := New[string](Undirected)
+	g.AddEdge("a", "b", 3)
+	g.AddEdge("b", "c", 1)
+
+	if !g.HasEdge("b", "a") || g.EdgeCost("b", "a") != 3 {
+		t.Error("expected an edge b -> a costing 3 in an undirected graph")
+	}
+
+	expected := []Edge[string]{{From: "a", To: "b", Cost: 3}, {From: "b", To: "c", Cost: 1}}
+	if !slices.Equal(g.Edges(), expected) {
+		t.Errorf("expected edges %v, got %v", expected, g.Edges())
+	}
+
+	if !g.RemoveEdge("b", "a") {
+		t.Error("expected edge b -> a to be removed")
+	}
+
+	if g.HasEdge("a", "b") || g.HasEdge("b", "a") {
+		t.Error("expected both directions to be removed")
+	}
+
+	if g.RemoveEdge("a", "b") {
+		t.Error("expected removing a missing edge to report false")
+	}
+}
+
+// a square of four cities, with a costly shortcut across.
+func cities() *Graph[string] {
+	g := New[string](Undirected)
+	g.AddEdge("amsterdam", "berlin", 6)
+	g.AddEdge("berlin", "copenhagen", 4)
+	g.AddEdge("amsterdam", "dublin", 8)
+	g.AddEdge("dublin", "copenhagen", 9)
+	g.AddEdge("amsterdam", "copenhagen", 12)
+	return g
+}
+
+func TestTarget(t *testing.T) {
+	g := cities()
+
+	s := pathfind.NewSolver[string](pathfind.AlgorithmAStar, "amsterdam", g.Target("copenhagen"))
+	path := s.Walk()
+
+	if !slices.Equal(path, []string{"copenhagen", "berlin", "amsterdam"}) {
+		t.Errorf("expected the way through berlin, got %v", path)
+	}
+
+	s = pathfind.NewSolver[string](pathfind.AlgorithmAStar, "copenhagen", g.Target("dublin"))
+	if path := s.Walk(); !slices.Equal(path, []string{"dublin", "copenhagen"}) {
+		t.Errorf("expected the direct way, got %v", path)
+	}
+}
+
+func TestHeuristic(t *testing.T) {
+	g := cities()
+
+	// an estimate that is far too high steers the search wrong.
+	g.Heuristic = func(from, to string) int {
+		if from == "berlin" {
+			return 100
+		}
+		return 0
+	}
+
+	s := pathfind.NewSolver[string](pathfind.AlgorithmAStar, "amsterdam", g.Target("copenhagen"))
+	if path := s.Walk(); !slices.Equal(path, []string{"copenhagen", "amsterdam"}) {
+		t.Errorf("expected the heuristic to steer the search away from berlin, got %v", path)
+	}
+
+	if violations := pathfind.CheckHeuristic[string](g.Target("copenhagen"), g.Nodes(), "copenhagen"); len(violations) == 0 {
+		t.Error("expected the heuristic to be reported as inadmissible")
+	}
+}
+
+// looks up edges of a complete graph, where every node has an edge to every
+// other node.
+func BenchmarkEdgeCost(b *testing.B) {
+	const size = 1000
+
+	g := New[int](Directed)
+	for from := 0; from < size; from++ {
+		for to := 0; to < size; to++ {
+			g.AddEdge(from, to, from+to)
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		from, to := i%size, (i*7)%size
+		if g.EdgeCost(from, to) != from+to {
+			b.Fatal("unexpected edge cost")
+		}
+	}
+}
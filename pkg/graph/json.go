@@ -0,0 +1,92 @@
+package graph
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var ErrorInvalidJSON = errors.New("graph: invalid json")
+
+// the JSON form of a graph:
+//
+//	{
+//	  "directed": true,
+//	  "nodes": ["a", "b", "c"],
+//	  "edges": [
+//	    {"from": "a", "to": "b", "cost": 3}
+//	  ]
+//	}
+//
+// Nodes only need listing when they have no edges. Cost defaults to 1 when
+// left out. Like CSV edge lists, the graph is directed unless "directed" is
+// set to false.
+type jsonGraph struct {
+	Directed *bool      `json:"directed,omitempty"`
+	Nodes    []string   `json:"nodes,omitempty"`
+	Edges    []jsonEdge `json:"edges"`
+}
+
+type jsonEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Cost *int   `json:"cost,omitempty"`
+}
+
+// reads a graph in the JSON form described at jsonGraph.
+func ReadJSON(r io.Reader) (*Graph[string], error) {
+	var doc jsonGraph
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrorInvalidJSON, err)
+	}
+
+	direction := Directed
+	if doc.Directed != nil && !*doc.Directed {
+		direction = Undirected
+	}
+
+	g := New[string](direction)
+	for _, n := range doc.Nodes {
+		g.AddNode(n)
+	}
+
+	for idx, e := range doc.Edges {
+		if e.From == "" || e.To == "" {
+			return nil, fmt.Errorf("%w: edge %d: from and to are required", ErrorInvalidJSON, idx)
+		}
+
+		cost := 1
+		if e.Cost != nil {
+			cost = *e.Cost
+		}
+
+		if err := g.AddEdge(e.From, e.To, cost); err != nil {
+			return nil, fmt.Errorf("%w: edge %d: %w", ErrorInvalidJSON, idx, err)
+		}
+	}
+
+	return g, nil
+}
+
+// writes the graph in the JSON form described at jsonGraph, listing all
+// nodes so those without edges are kept.
+func WriteJSON(w io.Writer, g *Graph[string]) error {
+	directed := g.Direction() == Directed
+	doc := jsonGraph{
+		Directed: &directed,
+		Nodes:    g.Nodes(),
+		Edges:    []jsonEdge{},
+	}
+
+	for _, e := range g.Edges() {
+		cost := e.Cost
+		doc.Edges = append(doc.Edges, jsonEdge{From: e.From, To: e.To, Cost: &cost})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(doc)
+}
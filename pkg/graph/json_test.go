@@ -0,0 +1,86 @@
+package graph
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReadJSON(t *testing.T) {
+	input := `{
+		"directed": false,
+		"nodes": ["lonely"],
+		"edges": [
+			{"from": "a", "to": "b", "cost": 3},
+			{"from": "b", "to": "c"}
+		]
+	}`
+
+	g, err := ReadJSON(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if g.Direction() != Undirected {
+		t.Error("expected the graph to be undirected")
+	}
+
+	if !g.HasNode("lonely") {
+		t.Error("expected nodes without edges to be read")
+	}
+
+	if g.EdgeCost("b", "a") != 3 {
+		t.Errorf("expected b -- a to cost 3, got %d", g.EdgeCost("b", "a"))
+	}
+
+	if g.EdgeCost("b", "c") != 1 {
+		t.Errorf("expected cost to default to 1, got %d", g.EdgeCost("b", "c"))
+	}
+}
+
+func TestReadJSONDirectedByDefault(t *testing.T) {
+	g, err := ReadJSON(strings.NewReader(`{"edges": [{"from": "a", "to": "b"}]}`))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if g.Direction() != Directed || g.HasEdge("b", "a") {
+		t.Error("expected the graph to be directed when leaving out directed")
+	}
+}
+
+func TestReadJSONErrors(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"[]",
+		`{"edges": [{"from": "a"}]}`,
+		`{"edges": [{"from": "a", "to": "b", "cost": -1}]}`,
+		`{"edges": [{"from": "a", "to": "b", "weight": 1}]}`,
+	} {
+		if _, err := ReadJSON(strings.NewReader(input)); !errors.Is(err, ErrorInvalidJSON) {
+			t.Errorf("expected ErrorInvalidJSON for %q, got %v", input, err)
+		}
+	}
+}
+
+func TestWriteJSONRoundTrip(t *testing.T) {
+	for _, direction := range []Direction{Directed, Undirected} {
+		g := New[string](direction)
+		g.AddNode("lonely")
+		g.AddEdge("a", "b", 3)
+		g.AddEdge("b", "c", 0)
+
+		var b bytes.Buffer
+		if err := WriteJSON(&b, g); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		read, err := ReadJSON(&b)
+		if err != nil {
+			t.Fatalf("expected written json to be readable, got %v", err)
+		}
+
+		assertSameGraph(t, g, read)
+	}
+}
@@ -0,0 +1,41 @@
+package graph
+
+// a graph searched towards one particular finish. Implements the adapter
+// interfaces of pathfind, so it can be passed to a Solver.
+type Target[T comparable] struct {
+	graph  *Graph[T]
+	finish T
+}
+
+// returns an adapter for searches ending at finish.
+func (g *Graph[T]) Target(finish T) *Target[T] {
+	return &Target[T]{
+		graph:  g,
+		finish: finish,
+	}
+}
+
+func (t *Target[T]) Neighbours(n T) []T {
+	return t.graph.Neighbours(n)
+}
+
+func (t *Target[T]) Predecessors(n T) []T {
+	return t.graph.Predecessors(n)
+}
+
+func (t *Target[T]) EdgeCost(from, to T) int {
+	return t.graph.EdgeCost(from, to)
+}
+
+func (t *Target[T]) CostToFinish(n T) int {
+	return t.graph.Estimate(n, t.finish)
+}
+
+func (t *Target[T]) IsFinish(n T) bool {
+	return n == t.finish
+}
+
+// lets a Batch run towards other finishes, using the same heuristic.
+func (t *Target[T]) Estimate(from, to T) int {
+	return t.graph.Estimate(from, to)
+}
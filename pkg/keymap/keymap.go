@@ -0,0 +1,100 @@
+package keymap
+
+import (
+	"github.com/tmw/pathfind/pkg/bitset"
+)
+
+// number of values per page, a power of two.
+const pageSize = 1 << 10
+
+// a map from keys to values. Given an index numbering the keys densely, it
+// keeps its values in pages of a slice instead, only allocating the pages
+// that are used. That takes far less memory than a map when keys cluster, as
+// with the cells of a grid reached by a search.
+type Map[K comparable, V any] struct {
+	index   func(K) int
+	pages   [][]V
+	present *bitset.Bitset
+	len     int
+
+	// only used without index
+	sparse map[K]V
+}
+
+// returns an empty map. Index may be nil, otherwise it has to return a unique,
+// non-negative number for every key.
+func New[K comparable, V any](index func(K) int) *Map[K, V] {
+	if index == nil {
+		return &Map[K, V]{sparse: make(map[K]V)}
+	}
+
+	return &Map[K, V]{
+		index:   index,
+		present: bitset.New(0),
+	}
+}
+
+func (m *Map[K, V]) Get(key K) (V, bool) {
+	if m.sparse != nil {
+		value, found := m.sparse[key]
+		return value, found
+	}
+
+	i := m.index(key)
+	if !m.present.Has(i) {
+		var zero V
+		return zero, false
+	}
+	return m.pages[i/pageSize][i%pageSize], true
+}
+
+func (m *Map[K, V]) Contains(key K) bool {
+	_, found := m.Get(key)
+	return found
+}
+
+func (m *Map[K, V]) Set(key K, value V) {
+	if m.sparse != nil {
+		m.sparse[key] = value
+		return
+	}
+
+	i := m.index(key)
+	page := i / pageSize
+	if page >= len(m.pages) {
+		m.pages = append(m.pages, make([][]V, page+1-len(m.pages))...)
+	}
+
+	if m.pages[page] == nil {
+		m.pages[page] = make([]V, pageSize)
+	}
+
+	if !m.present.Has(i) {
+		m.present.Set(i)
+		m.len++
+	}
+	m.pages[page][i%pageSize] = value
+}
+
+func (m *Map[K, V]) Delete(key K) {
+	if m.sparse != nil {
+		delete(m.sparse, key)
+		return
+	}
+
+	i := m.index(key)
+	if m.present.Has(i) {
+		m.present.Unset(i)
+		m.len--
+
+		var zero V
+		m.pages[i/pageSize][i%pageSize] = zero
+	}
+}
+
+func (m *Map[K, V]) Len() int {
+	if m.sparse != nil {
+		return len(m.sparse)
+	}
+	return m.len
+}
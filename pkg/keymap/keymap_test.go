@@ -0,0 +1,56 @@
+package keymap
+
+import (
+	"testing"
+)
+
+func maps() map[string]*Map[[2]int, string] {
+	return map[string]*Map[[2]int, string]{
+		"sparse": New[[2]int, string](nil),
+		"dense":  New[[2]int, string](func(k [2]int) int { return k[1]*100 + k[0] }),
+	}
+}
+
+func TestSetAndGet(t *testing.T) {
+	for name, m := range maps() {
+		m.Set([2]int{3, 4}, "red")
+		m.Set([2]int{99, 99}, "green")
+		m.Set([2]int{3, 4}, "orange")
+
+		if value, found := m.Get([2]int{3, 4}); !found || value != "orange" {
+			t.Errorf("%s: expected orange, got %q (found: %v)", name, value, found)
+		}
+
+		if value, found := m.Get([2]int{99, 99}); !found || value != "green" {
+			t.Errorf("%s: expected green, got %q (found: %v)", name, value, found)
+		}
+
+		for _, k := range [][2]int{{4, 3}, {0, 0}, {99, 200}} {
+			if m.Contains(k) {
+				t.Errorf("%s: expected no value for %v", name, k)
+			}
+		}
+
+		if m.Len() != 2 {
+			t.Errorf("%s: expected length of 2, got %d", name, m.Len())
+		}
+	}
+}
+
+func TestDelete(t *testing.T) {
+	for name, m := range maps() {
+		m.Set([2]int{3, 4}, "red")
+		m.Set([2]int{5, 6}, "green")
+		m.Delete([2]int{3, 4})
+		m.Delete([2]int{3, 4})
+		m.Delete([2]int{90, 90})
+
+		if m.Contains([2]int{3, 4}) {
+			t.Errorf("%s: expected 3,4 to be deleted", name)
+		}
+
+		if m.Len() != 1 {
+			t.Errorf("%s: expected length of 1, got %d", name, m.Len())
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package maze
+
+// carves a random depth-first path through the maze, backtracking whenever
+// it runs into a dead end. Yields long, winding corridors.
+func recursiveBacktracker(g *grid) {
+	visited := map[room]bool{{}: true}
+	stack := []room{{}}
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+
+		unvisited := []room{}
+		for _, n := range g.neighbours(current) {
+			if !visited[n] {
+				unvisited = append(unvisited, n)
+			}
+		}
+
+		if len(unvisited) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		next := unvisited[g.rand.Intn(len(unvisited))]
+		g.connect(current, next)
+		visited[next] = true
+		stack = append(stack, next)
+	}
+}
+
+// grows the maze from a single room, each time connecting a random room
+// bordering the maze to it. Yields many short dead ends.
+func prim(g *grid) {
+	type passage struct {
+		from, to room
+	}
+
+	in := make(map[room]bool)
+	frontier := []passage{}
+
+	add := func(r room) {
+		in[r] = true
+		for _, n := range g.neighbours(r) {
+			if !in[n] {
+				frontier = append(frontier, passage{from: r, to: n})
+			}
+		}
+	}
+
+	add(g.randomRoom())
+
+	for len(frontier) > 0 {
+		idx := g.rand.Intn(len(frontier))
+		p := frontier[idx]
+		frontier[idx] = frontier[len(frontier)-1]
+		frontier = frontier[:len(frontier)-1]
+
+		if in[p.to] {
+			continue
+		}
+
+		g.connect(p.from, p.to)
+		add(p.to)
+	}
+}
+
+// knocks down the walls in random order, unless the rooms on either side are
+// connected already.
+func kruskal(g *grid) {
+	type wall struct {
+		a, b room
+	}
+
+	walls := []wall{}
+	for _, r := range g.rooms() {
+		for _, n := range g.neighbours(r) {
+			if n.x > r.x || n.y > r.y {
+				walls = append(walls, wall{a: r, b: n})
+			}
+		}
+	}
+
+	g.rand.Shuffle(len(walls), func(i, j int) {
+		walls[i], walls[j] = walls[j], walls[i]
+	})
+
+	sets := newDisjointSet(g.rooms())
+	for _, w := range walls {
+		if sets.union(w.a, w.b) {
+			g.connect(w.a, w.b)
+		}
+	}
+}
+
+// adds loop-erased random walks to the maze until it covers every room.
+// Unlike the others, every possible maze is equally likely to come out.
+func wilson(g *grid) {
+	in := map[room]bool{g.randomRoom(): true}
+
+	for _, start := range g.rooms() {
+		if in[start] {
+			continue
+		}
+
+		// walk randomly until hitting the maze, remembering only the last
+		// direction taken from every room, which erases any loops.
+		next := make(map[room]room)
+		for current := start; !in[current]; {
+			neighbours := g.neighbours(current)
+			next[current] = neighbours[g.rand.Intn(len(neighbours))]
+			current = next[current]
+		}
+
+		for current := start; !in[current]; current = next[current] {
+			g.connect(current, next[current])
+			in[current] = true
+		}
+	}
+}
+
+type disjointSet struct {
+	parent map[room]room
+}
+
+func newDisjointSet(rooms []room) *disjointSet {
+	parent := make(map[room]room, len(rooms))
+	for _, r := range rooms {
+		parent[r] = r
+	}
+	return &disjointSet{parent: parent}
+}
+
+func (d *disjointSet) find(r room) room {
+	for d.parent[r] != r {
+		d.parent[r] = d.parent[d.parent[r]]
+		r = d.parent[r]
+	}
+	return r
+}
+
+// merges the sets of a and b, returning false if they were the same set.
+func (d *disjointSet) union(a, b room) bool {
+	rootA, rootB := d.find(a), d.find(b)
+	if rootA == rootB {
+		return false
+	}
+
+	d.parent[rootA] = rootB
+	return true
+}
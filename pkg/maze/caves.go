@@ -0,0 +1,189 @@
+package maze
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/tmw/pathfind"
+	"github.com/tmw/pathfind/pkg/arena"
+)
+
+var ErrorInvalidDensity = errors.New("maze: density must be between 0 and 1")
+
+// number of smoothing passes applied to the noise of a cave.
+const caveIterations = 4
+
+// a generated arena that is guaranteed to be solvable, along with the length
+// of its shortest path, to compare the results of solvers against.
+type Level struct {
+	Arena             *arena.Arena
+	OptimalPathLength int
+}
+
+// scatters obstacles over an arena of width by height cells, surrounded by a
+// wall. Every cell becomes an obstacle with the given probability. The start
+// is placed in the top left corner and the finish in the bottom right one,
+// carving a way through where the obstacles happen to separate the two.
+func Noise(width, height int, density float64, seed int64) (Level, error) {
+	if err := validate(width, height, density); err != nil {
+		return Level{}, err
+	}
+
+	return newLevel(noise(width, height, density, rand.New(rand.NewSource(seed))))
+}
+
+// generates cave-like arenas by smoothing noise of the given density using a
+// cellular automaton: every cell turns into a wall when most of the cells
+// around it are walls, and opens up otherwise. Like Noise, the start and
+// finish are placed in opposite corners and guaranteed to be connected.
+func Cave(width, height int, density float64, seed int64) (Level, error) {
+	if err := validate(width, height, density); err != nil {
+		return Level{}, err
+	}
+
+	open := noise(width, height, density, rand.New(rand.NewSource(seed)))
+	for i := 0; i < caveIterations; i++ {
+		open = smooth(open)
+	}
+
+	return newLevel(open)
+}
+
+func validate(width, height int, density float64) error {
+	if width < 3 || height < 3 || (width-2)*(height-2) < 2 {
+		return ErrorInvalidSize
+	}
+
+	if density < 0 || density > 1 {
+		return ErrorInvalidDensity
+	}
+
+	return nil
+}
+
+func noise(width, height int, density float64, r *rand.Rand) [][]bool {
+	open := make([][]bool, height)
+	for y := range open {
+		open[y] = make([]bool, width)
+		for x := range open[y] {
+			border := x == 0 || y == 0 || x == width-1 || y == height-1
+			open[y][x] = !border && r.Float64() >= density
+		}
+	}
+	return open
+}
+
+// applies a single pass of the cellular automaton. Cells outside the arena
+// count as walls, so the border stays closed.
+func smooth(open [][]bool) [][]bool {
+	next := make([][]bool, len(open))
+	for y := range open {
+		next[y] = make([]bool, len(open[y]))
+		for x := range open[y] {
+			walls := 0
+			for dy := -1; dy <= 1; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					ny, nx := y+dy, x+dx
+					if ny < 0 || nx < 0 || ny >= len(open) || nx >= len(open[ny]) || !open[ny][nx] {
+						walls++
+					}
+				}
+			}
+
+			border := x == 0 || y == 0 || y == len(open)-1 || x == len(open[y])-1
+			next[y][x] = !border && walls < 5
+		}
+	}
+	return next
+}
+
+type cell struct {
+	x, y int
+}
+
+// places start and finish in opposite corners, then makes sure they're
+// connected by solving the arena, carving a way through if needed.
+func newLevel(open [][]bool) (Level, error) {
+	start, finish := cell{x: 1, y: 1}, cell{x: len(open[0]) - 2, y: len(open) - 2}
+	open[start.y][start.x], open[finish.y][finish.x] = true, true
+
+	a, err := levelArena(open, start, finish)
+	if err != nil {
+		return Level{}, err
+	}
+
+	path := solve(a)
+	if len(path) == 0 {
+		for _, c := range carve(open, start, finish) {
+			open[c.y][c.x] = true
+		}
+
+		if a, err = levelArena(open, start, finish); err != nil {
+			return Level{}, err
+		}
+
+		path = solve(a)
+	}
+
+	return Level{Arena: a, OptimalPathLength: len(path) - 1}, nil
+}
+
+func levelArena(open [][]bool, start, finish cell) (*arena.Arena, error) {
+	cells := toCells(open)
+	cells[start.y][start.x] = arena.CellTypeStart
+	cells[finish.y][finish.x] = arena.CellTypeFinish
+
+	return arena.New(cells)
+}
+
+// finds the way from start to finish that runs through the fewest walls,
+// returning the cells along it. Stays clear of the border.
+func carve(open [][]bool, start, finish cell) []cell {
+	height, width := len(open), len(open[0])
+
+	// more than any path through open cells alone could ever cost.
+	wallCost := width * height
+
+	s := pathfind.NewSolver[cell](
+		pathfind.AlgorithmAStar,
+		start,
+		&pathfind.FuncAdapter[cell]{
+			NeighboursFn: func(c cell) []cell {
+				neighbours := []cell{}
+				for _, n := range []cell{{c.x, c.y - 1}, {c.x - 1, c.y}, {c.x, c.y + 1}, {c.x + 1, c.y}} {
+					if n.x > 0 && n.y > 0 && n.x < width-1 && n.y < height-1 {
+						neighbours = append(neighbours, n)
+					}
+				}
+				return neighbours
+			},
+
+			CostToFinishFn: func(c cell) int {
+				return max(finish.x-c.x, c.x-finish.x) + max(finish.y-c.y, c.y-finish.y)
+			},
+
+			IsFinishFn: func(c cell) bool {
+				return c == finish
+			},
+
+			EdgeCostFn: func(_, to cell) int {
+				if open[to.y][to.x] {
+					return 1
+				}
+				return wallCost
+			},
+		},
+	)
+
+	return s.Walk()
+}
+
+func solve(a *arena.Arena) []arena.Coordinate {
+	s := pathfind.NewSolver[arena.Coordinate](
+		pathfind.AlgorithmBFS,
+		a.StartCoordinate(),
+		arena.NewAdapter(a, a.FinishCoordinate()),
+	)
+
+	return s.Walk()
+}
@@ -0,0 +1,143 @@
+package maze
+
+import (
+	"testing"
+
+	"github.com/tmw/pathfind"
+	"github.com/tmw/pathfind/pkg/arena"
+)
+
+var generators = map[string]func(width, height int, density float64, seed int64) (Level, error){
+	"noise": Noise,
+	"cave":  Cave,
+}
+
+func TestGeneratedLevelsAreSolvable(t *testing.T) {
+	for name, generate := range generators {
+		for _, density := range []float64{0, 0.3, 0.45, 0.6, 1} {
+			for seed := int64(0); seed < 5; seed++ {
+				level, err := generate(40, 20, density, seed)
+				if err != nil {
+					t.Fatalf("%s %.2f: %v", name, density, err)
+				}
+
+				a := level.Arena
+				s := pathfind.NewSolver[arena.Coordinate](
+					pathfind.AlgorithmBFS,
+					a.StartCoordinate(),
+					arena.NewAdapter(a, a.FinishCoordinate()),
+				)
+
+				path := s.Walk()
+				if len(path) == 0 {
+					t.Fatalf("%s %.2f seed %d: expected level to be solvable", name, density, seed)
+				}
+
+				if len(path)-1 != level.OptimalPathLength {
+					t.Errorf("%s %.2f seed %d: expected optimal path length %d, solved %d", name, density, seed, level.OptimalPathLength, len(path)-1)
+				}
+
+				// nothing can beat walking straight from corner to corner.
+				if shortest := a.StartCoordinate().DistanceTo(a.FinishCoordinate()); level.OptimalPathLength < shortest {
+					t.Errorf("%s %.2f seed %d: path length %d is shorter than possible", name, density, seed, level.OptimalPathLength)
+				}
+			}
+		}
+	}
+}
+
+func TestNoiseDensity(t *testing.T) {
+	level, err := Noise(102, 102, 0.25, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	interior := 100 * 100
+	walls := interior - walkableCells(level.Arena)
+
+	if ratio := float64(walls) / float64(interior); ratio < 0.2 || ratio > 0.3 {
+		t.Errorf("expected about a quarter of the cells to be walls, got %.2f", ratio)
+	}
+}
+
+func TestLevelsAreReproducible(t *testing.T) {
+	for name, generate := range generators {
+		first, _ := generate(30, 15, 0.4, 11)
+		second, _ := generate(30, 15, 0.4, 11)
+
+		for y := 0; y < first.Arena.Height(); y++ {
+			for x := 0; x < first.Arena.Width(); x++ {
+				c := arena.NewCoordinate(x, y)
+				if first.Arena.CellTypeForCoordinate(c) != second.Arena.CellTypeForCoordinate(c) {
+					t.Fatalf("%s: expected the same seed to yield the same level", name)
+				}
+			}
+		}
+	}
+}
+
+func TestLevelValidation(t *testing.T) {
+	if _, err := Noise(3, 3, 0.2, 1); err != ErrorInvalidSize {
+		t.Errorf("expected ErrorInvalidSize but got %v", err)
+	}
+
+	if _, err := Cave(10, 10, 1.5, 1); err != ErrorInvalidDensity {
+		t.Errorf("expected ErrorInvalidDensity but got %v", err)
+	}
+}
+
+func TestCarvePrefersFloor(t *testing.T) {
+	tests := map[string]struct {
+		rows  []string
+		walls int
+	}{
+		"detour around the wall": {
+			rows: []string{
+				"#########",
+				"#...#...#",
+				"###.#.###",
+				"#...#...#",
+				"#.......#",
+				"#########",
+			},
+			walls: 0,
+		},
+		"through the thinnest wall": {
+			rows: []string{
+				"#########",
+				"#...#...#",
+				"###.#.###",
+				"#...#...#",
+				"#...#...#",
+				"#########",
+			},
+			walls: 1,
+		},
+	}
+
+	for name, td := range tests {
+		open := make([][]bool, len(td.rows))
+		for y, row := range td.rows {
+			open[y] = make([]bool, len(row))
+			for x := range row {
+				open[y][x] = row[x] != '#'
+			}
+		}
+
+		path := carve(open, cell{x: 1, y: 1}, cell{x: 7, y: 1})
+		if len(path) == 0 {
+			t.Fatalf("%s: expected a path to be carved", name)
+		}
+
+		walls := 0
+		for _, c := range path {
+			if !open[c.y][c.x] {
+				walls++
+			}
+		}
+
+		if walls != td.walls {
+			t.Errorf("%s: expected to carve through %d walls, carved %d along %v", name, td.walls, walls, path)
+		}
+	}
+}
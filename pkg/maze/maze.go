@@ -0,0 +1,130 @@
+// Package maze generates perfect mazes (mazes with exactly one path between
+// any two rooms) as arenas, to be used as levels or test input.
+package maze
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/tmw/pathfind/pkg/arena"
+)
+
+var ErrorInvalidSize = errors.New("maze: needs at least two rooms, to hold both start and finish")
+
+type Algorithm int
+
+const (
+	AlgorithmRecursiveBacktracker Algorithm = iota
+	AlgorithmPrim
+	AlgorithmKruskal
+	AlgorithmWilson
+)
+
+// generates a maze of width by height rooms using the given algorithm. Rooms
+// are separated by walls, so the resulting arena measures 2*width+1 by
+// 2*height+1 cells. The start is placed in the top left room and the finish
+// in the bottom right one. The same seed always yields the same maze.
+func Generate(algorithm Algorithm, width, height int, seed int64) (*arena.Arena, error) {
+	if width < 1 || height < 1 || width*height < 2 {
+		return nil, ErrorInvalidSize
+	}
+
+	g := newGrid(width, height, rand.New(rand.NewSource(seed)))
+
+	switch algorithm {
+	case AlgorithmPrim:
+		prim(g)
+
+	case AlgorithmKruskal:
+		kruskal(g)
+
+	case AlgorithmWilson:
+		wilson(g)
+
+	default:
+		recursiveBacktracker(g)
+	}
+
+	return g.toArena()
+}
+
+type room struct {
+	x, y int
+}
+
+// the rooms of the maze along with the passages carved between them.
+type grid struct {
+	width, height int
+	rand          *rand.Rand
+
+	// cells of the arena, true where carved open.
+	open [][]bool
+}
+
+func newGrid(width, height int, r *rand.Rand) *grid {
+	open := make([][]bool, 2*height+1)
+	for y := range open {
+		open[y] = make([]bool, 2*width+1)
+	}
+
+	return &grid{
+		width:  width,
+		height: height,
+		rand:   r,
+		open:   open,
+	}
+}
+
+func (g *grid) rooms() []room {
+	rooms := make([]room, 0, g.width*g.height)
+	for y := 0; y < g.height; y++ {
+		for x := 0; x < g.width; x++ {
+			rooms = append(rooms, room{x: x, y: y})
+		}
+	}
+	return rooms
+}
+
+func (g *grid) neighbours(r room) []room {
+	neighbours := []room{}
+	for _, n := range []room{{r.x, r.y - 1}, {r.x - 1, r.y}, {r.x, r.y + 1}, {r.x + 1, r.y}} {
+		if n.x >= 0 && n.y >= 0 && n.x < g.width && n.y < g.height {
+			neighbours = append(neighbours, n)
+		}
+	}
+	return neighbours
+}
+
+func (g *grid) randomRoom() room {
+	return room{x: g.rand.Intn(g.width), y: g.rand.Intn(g.height)}
+}
+
+// carves out both rooms and the wall between them.
+func (g *grid) connect(a, b room) {
+	g.open[2*a.y+1][2*a.x+1] = true
+	g.open[2*b.y+1][2*b.x+1] = true
+	g.open[a.y+b.y+1][a.x+b.x+1] = true
+}
+
+func (g *grid) toArena() (*arena.Arena, error) {
+	cells := toCells(g.open)
+	cells[1][1] = arena.CellTypeStart
+	cells[2*g.height-1][2*g.width-1] = arena.CellTypeFinish
+
+	return arena.New(cells)
+}
+
+// turns a grid of open cells into arena cells, with walls everywhere else.
+func toCells(open [][]bool) [][]arena.CellType {
+	cells := make([][]arena.CellType, len(open))
+	for y := range open {
+		cells[y] = make([]arena.CellType, len(open[y]))
+		for x := range open[y] {
+			cells[y][x] = arena.CellTypeNonWalkable
+			if open[y][x] {
+				cells[y][x] = arena.CellTypeWalkable
+			}
+		}
+	}
+	return cells
+}
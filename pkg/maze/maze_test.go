@@ -0,0 +1,105 @@
+package maze
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tmw/pathfind/pkg/arena"
+)
+
+var algorithms = map[string]Algorithm{
+	"recursive backtracker": AlgorithmRecursiveBacktracker,
+	"prim":                  AlgorithmPrim,
+	"kruskal":               AlgorithmKruskal,
+	"wilson":                AlgorithmWilson,
+}
+
+// returns the number of walkable cells reachable from the start.
+func reachableCells(a *arena.Arena) int {
+	seen := map[arena.Coordinate]bool{a.StartCoordinate(): true}
+	queue := []arena.Coordinate{a.StartCoordinate()}
+
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+
+		for _, n := range a.NeighboursOfCoordinate(c) {
+			if a.IsWalkable(n) && !seen[n] {
+				seen[n] = true
+				queue = append(queue, n)
+			}
+		}
+	}
+
+	return len(seen)
+}
+
+func walkableCells(a *arena.Arena) int {
+	count := 0
+	for y := 0; y < a.Height(); y++ {
+		for x := 0; x < a.Width(); x++ {
+			if a.IsWalkable(arena.NewCoordinate(x, y)) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func TestGenerateYieldsPerfectMazes(t *testing.T) {
+	sizes := [][2]int{{1, 2}, {1, 7}, {6, 1}, {9, 5}, {20, 20}}
+
+	for name, algorithm := range algorithms {
+		for _, size := range sizes {
+			a, err := Generate(algorithm, size[0], size[1], 42)
+			if err != nil {
+				t.Fatalf("%s %v: %v", name, size, err)
+			}
+
+			if a.Width() != 2*size[0]+1 || a.Height() != 2*size[1]+1 {
+				t.Errorf("%s %v: unexpected arena size %dx%d", name, size, a.Width(), a.Height())
+			}
+
+			// a spanning tree of the rooms has exactly one passage less than
+			// there are rooms, all of which can be reached.
+			rooms := size[0] * size[1]
+			if walkable := walkableCells(a); walkable != 2*rooms-1 {
+				t.Errorf("%s %v: expected %d walkable cells but got %d", name, size, 2*rooms-1, walkable)
+			}
+
+			if reachable := reachableCells(a); reachable != 2*rooms-1 {
+				t.Errorf("%s %v: expected all %d cells to be reachable, got %d", name, size, 2*rooms-1, reachable)
+			}
+		}
+	}
+}
+
+func TestGenerateIsReproducible(t *testing.T) {
+	render := func(a *arena.Arena) string {
+		var out strings.Builder
+		a.Render(&out)
+		return out.String()
+	}
+
+	for name, algorithm := range algorithms {
+		first, _ := Generate(algorithm, 12, 8, 7)
+		second, _ := Generate(algorithm, 12, 8, 7)
+		other, _ := Generate(algorithm, 12, 8, 8)
+
+		if render(first) != render(second) {
+			t.Errorf("%s: expected the same seed to yield the same maze", name)
+		}
+
+		if render(first) == render(other) {
+			t.Errorf("%s: expected different seeds to yield different mazes", name)
+		}
+	}
+}
+
+func TestGenerateInvalidSize(t *testing.T) {
+	for _, size := range [][2]int{{0, 4}, {1, 1}, {3, -1}} {
+		if _, err := Generate(AlgorithmPrim, size[0], size[1], 1); err != ErrorInvalidSize {
+			t.Errorf("%v: expected ErrorInvalidSize but got %v", size, err)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package prioqueue
+
+// a priority queue holding at most one value per key, which keeps track of
+// where every key sits in the heap. That makes looking up and changing the
+// priority of a queued key cheap, compared to scanning with IndexFunc.
+//
+// Every node of the heap has d children, two unless created through
+// NewKeyedDAryOf. Entries are stored by value, so pushing only allocates
+// when the heap grows.
+type KeyedOf[K comparable, V any, P Number] struct {
+	d       int
+	entries []entry[K, V, P]
+	index   map[K]int
+}
+
+// a keyed priority queue with int priorities.
+type Keyed[K comparable, V any] struct {
+	KeyedOf[K, V, int]
+}
+
+type entry[K comparable, V any, P Number] struct {
+	key   K
+	value V
+	prio  P
+}
+
+func NewKeyedOf[K comparable, V any, P Number]() *KeyedOf[K, V, P] {
+	return NewKeyedDAryOf[K, V, P](2)
+}
+
+// returns a keyed priority queue with d children per heap node, falling
+// back to 4 when d is less than 2. With more than two the heap is
+// shallower, and the children of a node sit next to each other in memory,
+// which pays off when pops dominate.
+func NewKeyedDAryOf[K comparable, V any, P Number](d int) *KeyedOf[K, V, P] {
+	if d < 2 {
+		d = 4
+	}
+
+	return &KeyedOf[K, V, P]{
+		d:     d,
+		index: make(map[K]int),
+	}
+}
+
+func NewKeyed[K comparable, V any]() *Keyed[K, V] {
+	return &Keyed[K, V]{*NewKeyedOf[K, V, int]()}
+}
+
+// queues value under key, or replaces the value and priority if the key is
+// queued already.
+func (p *KeyedOf[K, V, P]) Push(key K, value V, prio P) {
+	if idx, found := p.index[key]; found {
+		old := p.entries[idx].prio
+		p.entries[idx].value = value
+		p.entries[idx].prio = prio
+
+		if prio < old {
+			p.up(idx)
+		} else {
+			p.down(idx)
+		}
+		return
+	}
+
+	p.entries = append(p.entries, entry[K, V, P]{key: key, value: value, prio: prio})
+	p.index[key] = len(p.entries) - 1
+	p.up(len(p.entries) - 1)
+}
+
+// removes and returns the key with the lowest priority, and its value.
+func (p *KeyedOf[K, V, P]) Pop() (K, V) {
+	top := p.entries[0]
+	last := len(p.entries) - 1
+
+	p.swap(0, last)
+	p.entries[last] = entry[K, V, P]{}
+	p.entries = p.entries[:last]
+	delete(p.index, top.key)
+
+	if len(p.entries) > 0 {
+		p.down(0)
+	}
+
+	return top.key, top.value
+}
+
+func (p *KeyedOf[K, V, P]) Contains(key K) bool {
+	_, found := p.index[key]
+	return found
+}
+
+// returns the value queued under key, if any.
+func (p *KeyedOf[K, V, P]) Get(key K) (V, bool) {
+	idx, found := p.index[key]
+	if !found {
+		var zero V
+		return zero, false
+	}
+	return p.entries[idx].value, true
+}
+
+// returns the priority of key, if it is queued.
+func (p *KeyedOf[K, V, P]) Priority(key K) (P, bool) {
+	idx, found := p.index[key]
+	if !found {
+		var zero P
+		return zero, false
+	}
+	return p.entries[idx].prio, true
+}
+
+// lowers the priority of a queued key. Reports false, leaving the queue
+// untouched, when the key is not queued or prio is not lower than its
+// current priority.
+func (p *KeyedOf[K, V, P]) DecreaseKey(key K, prio P) bool {
+	idx, found := p.index[key]
+	if !found || prio >= p.entries[idx].prio {
+		return false
+	}
+
+	p.entries[idx].prio = prio
+	p.up(idx)
+	return true
+}
+
+func (p *KeyedOf[K, V, P]) Len() int { return len(p.entries) }
+
+func (p *KeyedOf[K, V, P]) up(idx int) {
+	for idx > 0 {
+		parent := (idx - 1) / p.d
+		if p.entries[parent].prio <= p.entries[idx].prio {
+			return
+		}
+
+		p.swap(idx, parent)
+		idx = parent
+	}
+}
+
+func (p *KeyedOf[K, V, P]) down(idx int) {
+	for {
+		smallest := idx
+		first := idx*p.d + 1
+		for child := first; child < first+p.d && child < len(p.entries); child++ {
+			if p.entries[child].prio < p.entries[smallest].prio {
+				smallest = child
+			}
+		}
+
+		if smallest == idx {
+			return
+		}
+
+		p.swap(idx, smallest)
+		idx = smallest
+	}
+}
+
+func (p *KeyedOf[K, V, P]) swap(i, j int) {
+	p.entries[i], p.entries[j] = p.entries[j], p.entries[i]
+	p.index[p.entries[i].key] = i
+	p.index[p.entries[j].key] = j
+}
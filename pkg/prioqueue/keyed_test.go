@@ -0,0 +1,148 @@
+package prioqueue
+
+import (
+	"testing"
+)
+
+func popAllKeyed[K comparable, V any](q *Keyed[K, V]) []K {
+	res := make([]K, q.Len())
+	for i := 0; i < len(res); i++ {
+		res[i], _ = q.Pop()
+	}
+	return res
+}
+
+func TestKeyedPushingAndPoppingInOrder(t *testing.T) {
+	q := NewKeyed[string, int]()
+	q.Push("first", 1, 30)
+	q.Push("second", 2, 20)
+	q.Push("third", 3, 10)
+
+	key, value := q.Pop()
+	if key != "third" || value != 3 {
+		t.Errorf("expected third with value 3 but got %s with value %d", key, value)
+	}
+
+	assertEqual(t, popAllKeyed(q), []string{"second", "first"})
+}
+
+func TestKeyedPushReplacesExistingKey(t *testing.T) {
+	q := NewKeyed[string, int]()
+	q.Push("red", 1, 10)
+	q.Push("green", 2, 20)
+	q.Push("red", 3, 30)
+
+	if q.Len() != 2 {
+		t.Errorf("expected length of 2, got %d", q.Len())
+	}
+
+	if value, _ := q.Get("red"); value != 3 {
+		t.Errorf("expected red to hold value 3, got %d", value)
+	}
+
+	assertEqual(t, popAllKeyed(q), []string{"green", "red"})
+}
+
+func TestKeyedContains(t *testing.T) {
+	q := NewKeyed[string, int]()
+	q.Push("red", 1, 10)
+	q.Push("green", 2, 20)
+
+	if !q.Contains("green") {
+		t.Error("expected green to be queued")
+	}
+
+	q.Pop()
+	if q.Contains("red") {
+		t.Error("expected red to be gone after popping it")
+	}
+
+	if _, found := q.Get("red"); found {
+		t.Error("expected no value for red after popping it")
+	}
+}
+
+func TestKeyedPriority(t *testing.T) {
+	q := NewKeyed[string, int]()
+	q.Push("red", 1, 10)
+	q.Push("green", 2, 20)
+
+	if prio, found := q.Priority("green"); !found || prio != 20 {
+		t.Errorf("expected priority 20 for green, got %d (found: %v)", prio, found)
+	}
+
+	if _, found := q.Priority("yellow"); found {
+		t.Error("expected no priority for yellow")
+	}
+}
+
+func TestKeyedDecreaseKey(t *testing.T) {
+	q := NewKeyed[string, int]()
+	q.Push("red", 1, 10)
+	q.Push("green", 2, 20)
+	q.Push("orange", 3, 30)
+	q.Push("pink", 4, 40)
+
+	if q.DecreaseKey("pink", 50) {
+		t.Error("expected increasing the priority of pink to be refused")
+	}
+
+	if q.DecreaseKey("yellow", 5) {
+		t.Error("expected decreasing the priority of an unknown key to be refused")
+	}
+
+	if !q.DecreaseKey("orange", 5) {
+		t.Error("expected the priority of orange to be decreased")
+	}
+
+	assertEqual(t, popAllKeyed(q), []string{"orange", "red", "green", "pink"})
+}
+
+// lowers the priority of every queued key once, the way a search finds
+// cheaper routes to nodes already on its frontier.
+func BenchmarkDecreaseKey(b *testing.B) {
+	const size = 2000
+
+	b.Run("IndexFunc", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			q := New[int]()
+			for k := 0; k < size; k++ {
+				q.Push(k, size+k)
+			}
+
+			for k := 0; k < size; k++ {
+				idx := q.IndexFunc(func(v int) bool { return v == size-k-1 })
+				q.UpdateAtIndex(idx, size-k-1, k)
+			}
+		}
+	})
+
+	b.Run("Keyed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			q := NewKeyed[int, int]()
+			for k := 0; k < size; k++ {
+				q.Push(k, k, size+k)
+			}
+
+			for k := 0; k < size; k++ {
+				q.DecreaseKey(size-k-1, k)
+			}
+		}
+	})
+}
+
+func TestKeyedFloatPriorities(t *testing.T) {
+	q := NewKeyedOf[string, int, float32]()
+	q.Push("red", 1, 1.5)
+	q.Push("green", 2, 1.25)
+	q.DecreaseKey("red", 0.5)
+
+	if prio, _ := q.Priority("red"); prio != 0.5 {
+		t.Errorf("expected priority 0.5 for red, got %f", prio)
+	}
+
+	key, _ := q.Pop()
+	if key != "red" {
+		t.Errorf("expected red first, got %s", key)
+	}
+}
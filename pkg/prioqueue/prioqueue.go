@@ -4,24 +4,41 @@ import (
 	"container/heap"
 )
 
+// the priority types a queue can be ordered by.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// a priority queue ordered by priorities of type P, lowest first.
+type PrioqueueOf[T comparable, P Number] struct {
+	inner pq[T, P]
+}
+
+func NewOf[T comparable, P Number]() *PrioqueueOf[T, P] {
+	return &PrioqueueOf[T, P]{inner: pq[T, P]{}}
+}
+
+// a priority queue with int priorities.
 type Prioqueue[T comparable] struct {
-	inner pq[T]
+	PrioqueueOf[T, int]
 }
 
 func New[T comparable]() *Prioqueue[T] {
-	return &Prioqueue[T]{inner: pq[T]{}}
+	return &Prioqueue[T]{*NewOf[T, int]()}
 }
 
-func (p *Prioqueue[T]) Push(item T, prio int) {
+func (p *PrioqueueOf[T, P]) Push(item T, prio P) {
 	n := newNode(item, prio)
 	heap.Push(&p.inner, n)
 }
 
-func (p *Prioqueue[T]) Pop() T {
+func (p *PrioqueueOf[T, P]) Pop() T {
 	return p.popItem().Value
 }
 
-func (p *Prioqueue[T]) IndexFunc(fn func(T) bool) int {
+func (p *PrioqueueOf[T, P]) IndexFunc(fn func(T) bool) int {
 	for idx := range p.inner {
 		if fn(p.inner[idx].Value) {
 			return idx
@@ -30,70 +47,70 @@ func (p *Prioqueue[T]) IndexFunc(fn func(T) bool) int {
 	return -1
 }
 
-func (p *Prioqueue[T]) PeekItem(idx int) T {
+func (p *PrioqueueOf[T, P]) PeekItem(idx int) T {
 	return p.inner[idx].Value
 }
 
-func (p *Prioqueue[T]) PriorityOfItem(idx int) int {
+func (p *PrioqueueOf[T, P]) PriorityOfItem(idx int) P {
 	return p.inner[idx].priority
 }
 
-func (p *Prioqueue[T]) UpdateAtIndex(idx int, item T, prio int) {
+func (p *PrioqueueOf[T, P]) UpdateAtIndex(idx int, item T, prio P) {
 	p.inner[idx].Value = item
 	p.inner[idx].priority = prio
 	heap.Fix(&p.inner, idx)
 }
 
-func (p *Prioqueue[T]) popItem() item[T] {
+func (p *PrioqueueOf[T, P]) popItem() item[T, P] {
 	r := heap.Pop(&p.inner)
-	return *r.(*item[T])
+	return *r.(*item[T, P])
 }
 
-func (p *Prioqueue[T]) Len() int { return p.inner.Len() }
+func (p *PrioqueueOf[T, P]) Len() int { return p.inner.Len() }
 
 // inner implementation
-type item[T comparable] struct {
+type item[T any, P Number] struct {
 	Value    T
-	priority int
+	priority P
 	index    int
 }
 
-func (i item[T]) Priority() int {
+func (i item[T, P]) Priority() P {
 	return i.priority
 }
 
-func newNode[T comparable](val T, prio int) *item[T] {
-	return &item[T]{
+func newNode[T any, P Number](val T, prio P) *item[T, P] {
+	return &item[T, P]{
 		Value:    val,
 		priority: prio,
 	}
 }
 
-type pq[T comparable] []*item[T]
+type pq[T any, P Number] []*item[T, P]
 
-func (p pq[T]) Len() int {
+func (p pq[T, P]) Len() int {
 	return len(p)
 }
 
-func (p pq[T]) Less(i, j int) bool {
+func (p pq[T, P]) Less(i, j int) bool {
 	a, b := p[i], p[j]
 	return a.priority < b.priority
 }
 
-func (p pq[T]) Swap(i, j int) {
+func (p pq[T, P]) Swap(i, j int) {
 	p[i], p[j] = p[j], p[i]
 	p[i].index = i
 	p[j].index = j
 }
 
-func (p *pq[T]) Push(x any) {
+func (p *pq[T, P]) Push(x any) {
 	n := len(*p)
-	item := x.(*item[T])
+	item := x.(*item[T, P])
 	item.index = n
 	*p = append(*p, item)
 }
 
-func (p *pq[T]) Pop() any {
+func (p *pq[T, P]) Pop() any {
 	old := *p
 	n := len(old)
 	item := old[n-1]
@@ -114,3 +114,20 @@ func TestUpdateAtIndex(t *testing.T) {
 	actual, expected := popAll(q), []string{"red", "orange", "pink", "blue"}
 	assertEqual(t, expected, actual)
 }
+
+func TestFloatPriorities(t *testing.T) {
+	q := NewOf[string, float64]()
+	q.Push("far", 2.5)
+	q.Push("near", 0.25)
+	q.Push("between", 1.5)
+
+	if q.PriorityOfItem(0) != 0.25 {
+		t.Errorf("expected the lowest priority of 0.25 up front, got: %f", q.PriorityOfItem(0))
+	}
+
+	out := make([]string, q.Len())
+	for i := range out {
+		out[i] = q.Pop()
+	}
+	assertEqual(t, out, []string{"near", "between", "far"})
+}
@@ -1,27 +1,116 @@
 package queue
 
+// a double ended queue backed by a growable ring buffer. Pushing and popping
+// at either end is amortised O(1), and popped slots are cleared so they
+// don't keep their items reachable.
 type Queue[T any] struct {
 	elem []T
+	head int
+	len  int
 }
 
 func New[T any](items ...T) Queue[T] {
-	return Queue[T]{elem: items}
+	q := Queue[T]{}
+	q.Push(items...)
+	return q
 }
 
+// appends items to the back of the queue.
 func (q *Queue[T]) Push(items ...T) {
-	q.elem = append(q.elem, items...)
+	q.reserve(len(items))
+	for _, item := range items {
+		q.elem[q.index(q.len)] = item
+		q.len++
+	}
 }
 
+// prepends items to the front of the queue, one by one, so the last item
+// ends up in front.
+func (q *Queue[T]) PushFront(items ...T) {
+	q.reserve(len(items))
+	for _, item := range items {
+		q.head = q.index(len(q.elem) - 1)
+		q.elem[q.head] = item
+		q.len++
+	}
+}
+
+// removes and returns the item in front.
 func (q *Queue[T]) Pop() T {
-	item := q.elem[0]
-	q.elem = q.elem[1:]
+	item := q.Peek()
+
+	var zero T
+	q.elem[q.head] = zero
+	q.head = q.index(1)
+	q.len--
+
 	return item
 }
 
+// removes and returns the item at the back.
+func (q *Queue[T]) PopBack() T {
+	item := q.PeekBack()
+
+	var zero T
+	q.elem[q.index(q.len-1)] = zero
+	q.len--
+
+	return item
+}
+
+// returns the item in front without removing it.
+func (q *Queue[T]) Peek() T {
+	if q.len == 0 {
+		panic("queue: Peek called on empty queue")
+	}
+	return q.elem[q.head]
+}
+
+// returns the item at the back without removing it.
+func (q *Queue[T]) PeekBack() T {
+	if q.len == 0 {
+		panic("queue: PeekBack called on empty queue")
+	}
+	return q.elem[q.index(q.len-1)]
+}
+
+// removes all items, keeping the capacity around for reuse.
+func (q *Queue[T]) Clear() {
+	clear(q.elem)
+	q.head = 0
+	q.len = 0
+}
+
 func (q *Queue[T]) Len() int {
-	return len(q.elem)
+	return q.len
 }
 
 func (q *Queue[T]) Empty() bool {
 	return q.Len() == 0
 }
+
+// returns the position in elem of the item offset places behind the head.
+// The capacity is always a power of two, so wrapping around is a mask.
+func (q *Queue[T]) index(offset int) int {
+	return (q.head + offset) & (len(q.elem) - 1)
+}
+
+// makes room for n more items, doubling the capacity until they fit.
+func (q *Queue[T]) reserve(n int) {
+	if q.len+n <= len(q.elem) {
+		return
+	}
+
+	size := max(len(q.elem), 8)
+	for size < q.len+n {
+		size *= 2
+	}
+	elem := make([]T, size)
+
+	// the items wrap around the end of elem when the head isn't at 0.
+	tail := copy(elem, q.elem[q.head:min(q.head+q.len, len(q.elem))])
+	copy(elem[tail:], q.elem[:q.len-tail])
+
+	q.elem = elem
+	q.head = 0
+}
@@ -44,3 +44,105 @@ func TestPushAndPop(t *testing.T) {
 		t.Errorf("%+v does not equal %+v", actual, expected)
 	}
 }
+
+func TestPushFrontAndPopBack(t *testing.T) {
+	q := New[int](3, 4)
+	q.PushFront(2, 1)
+
+	if q.Peek() != 1 || q.PeekBack() != 4 {
+		t.Errorf("expected 1 in front and 4 at the back, got %d and %d", q.Peek(), q.PeekBack())
+	}
+
+	expected := []int{4, 3, 2, 1}
+	actual := []int{}
+
+	for !q.Empty() {
+		actual = append(actual, q.PopBack())
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("%+v does not equal %+v", actual, expected)
+	}
+}
+
+func TestWrapsAroundWhileGrowing(t *testing.T) {
+	q := New[int]()
+	expected := []int{}
+	actual := []int{}
+
+	// keep popping a few items so the head moves along, while the queue
+	// grows past its capacity a number of times.
+	for i := 0; i < 100; i++ {
+		q.Push(i)
+		expected = append(expected, i)
+
+		if i%3 == 0 {
+			actual = append(actual, q.Pop())
+		}
+	}
+
+	for !q.Empty() {
+		actual = append(actual, q.Pop())
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("%+v does not equal %+v", actual, expected)
+	}
+}
+
+func TestClear(t *testing.T) {
+	q := New[int](1, 2, 3)
+	capacity := len(q.elem)
+	q.Clear()
+
+	if !q.Empty() {
+		t.Error("expected queue to be empty")
+	}
+
+	if len(q.elem) != capacity {
+		t.Errorf("expected capacity of %d to be kept, got %d", capacity, len(q.elem))
+	}
+
+	q.Push(4)
+	if q.Pop() != 4 {
+		t.Error("expected queue to be usable after clearing")
+	}
+}
+
+func TestPopReleasesItems(t *testing.T) {
+	item := 1
+	q := New[*int](&item, &item)
+	q.Pop()
+	q.PopBack()
+
+	for idx := range q.elem {
+		if q.elem[idx] != nil {
+			t.Errorf("expected slot %d to be cleared after popping", idx)
+		}
+	}
+}
+
+func TestPeekOnEmptyQueuePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected Peek on an empty queue to panic")
+		}
+	}()
+
+	q := New[int]()
+	q.Peek()
+}
+
+// keeps a steady number of items queued, like the frontier of a BFS.
+func BenchmarkPushPop(b *testing.B) {
+	b.ReportAllocs()
+	q := New[int]()
+	for i := 0; i < 1000; i++ {
+		q.Push(i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q.Push(q.Pop())
+	}
+}
@@ -0,0 +1,71 @@
+package tsp
+
+// finds the optimal open path from node 0 through all other nodes using the
+// Held-Karp dynamic programming algorithm. Runs in O(2^n * n^2) time and
+// O(2^n * n) memory, so only use it for small inputs. Returns no order and a
+// cost of Infinity when not every node can be reached.
+func HeldKarp(dist [][]int) ([]int, int) {
+	n := len(dist)
+	if n <= 1 {
+		return make([]int, n), 0
+	}
+
+	// node i (for i >= 1) is represented by bit i-1 of the visited mask.
+	full := 1<<(n-1) - 1
+	cost := make([][]int, full+1)
+	parent := make([][]int, full+1)
+	for mask := range cost {
+		cost[mask] = make([]int, n)
+		parent[mask] = make([]int, n)
+		for last := range cost[mask] {
+			cost[mask][last] = Infinity
+		}
+	}
+
+	for last := 1; last < n; last++ {
+		cost[1<<(last-1)][last] = dist[0][last]
+	}
+
+	for mask := 1; mask <= full; mask++ {
+		for last := 1; last < n; last++ {
+			if mask&(1<<(last-1)) == 0 || cost[mask][last] >= Infinity {
+				continue
+			}
+
+			for next := 1; next < n; next++ {
+				if mask&(1<<(next-1)) != 0 {
+					continue
+				}
+
+				nextMask := mask | 1<<(next-1)
+				if c := cost[mask][last] + dist[last][next]; c < cost[nextMask][next] {
+					cost[nextMask][next] = c
+					parent[nextMask][next] = last
+				}
+			}
+		}
+	}
+
+	best, bestLast := Infinity, 1
+	for last := 1; last < n; last++ {
+		if cost[full][last] < best {
+			best, bestLast = cost[full][last], last
+		}
+	}
+
+	// the parents of unreached nodes were never set, so there's nothing to
+	// walk back.
+	if best >= Infinity {
+		return nil, Infinity
+	}
+
+	// walk the parents back from the last node to reconstruct the order.
+	order := make([]int, n)
+	mask, last := full, bestLast
+	for idx := n - 1; idx > 0; idx-- {
+		order[idx] = last
+		mask, last = mask&^(1<<(last-1)), parent[mask][last]
+	}
+
+	return order, best
+}
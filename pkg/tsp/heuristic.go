@@ -0,0 +1,71 @@
+package tsp
+
+import "slices"
+
+// builds an open path from node 0 by repeatedly moving to the closest node
+// that has not been visited yet.
+func NearestNeighbour(dist [][]int) []int {
+	n := len(dist)
+	if n == 0 {
+		return []int{}
+	}
+
+	visited := make([]bool, n)
+	visited[0] = true
+	order := []int{0}
+
+	for len(order) < n {
+		current, next := order[len(order)-1], -1
+		for candidate := range dist {
+			if visited[candidate] {
+				continue
+			}
+
+			if next == -1 || dist[current][candidate] < dist[current][next] {
+				next = candidate
+			}
+		}
+
+		visited[next] = true
+		order = append(order, next)
+	}
+
+	return order
+}
+
+// improves an open path by reversing stretches of it for as long as doing so
+// lowers its cost. The first node stays in place. Distances may be asymmetric,
+// so the cost of the reversed stretch itself is taken into account as well.
+func TwoOpt(dist [][]int, order []int) []int {
+	order = append([]int{}, order...)
+	n := len(order)
+
+	for improved := true; improved; {
+		improved = false
+
+		for i := 1; i < n-1; i++ {
+			// cost of the stretch i..j walked forwards and backwards.
+			forward, backward := 0, 0
+
+			for j := i + 1; j < n; j++ {
+				forward += dist[order[j-1]][order[j]]
+				backward += dist[order[j]][order[j-1]]
+
+				before := dist[order[i-1]][order[i]] + forward
+				after := dist[order[i-1]][order[j]] + backward
+				if j+1 < n {
+					before += dist[order[j]][order[j+1]]
+					after += dist[order[i]][order[j+1]]
+				}
+
+				if after < before {
+					slices.Reverse(order[i : j+1])
+					forward, backward = backward, forward
+					improved = true
+				}
+			}
+		}
+	}
+
+	return order
+}
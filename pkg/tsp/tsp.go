@@ -0,0 +1,32 @@
+package tsp
+
+import "math"
+
+// distance between two nodes that can not reach one another. Large enough to
+// never be preferred, small enough to be summed without overflowing.
+const Infinity = math.MaxInt32
+
+// the largest number of nodes that Solve still orders exactly.
+const HeldKarpLimit = 16
+
+// orders the nodes of the distance matrix into the cheapest open path that
+// starts at node 0 and visits every other node exactly once. dist[i][j] holds
+// the distance from node i to node j and does not need to be symmetric.
+// Small inputs are solved exactly, larger ones approximated.
+func Solve(dist [][]int) ([]int, int) {
+	if len(dist) <= HeldKarpLimit {
+		return HeldKarp(dist)
+	}
+
+	order := TwoOpt(dist, NearestNeighbour(dist))
+	return order, Cost(dist, order)
+}
+
+// returns the cost of visiting the nodes in the given order.
+func Cost(dist [][]int, order []int) int {
+	cost := 0
+	for idx := 1; idx < len(order); idx++ {
+		cost += dist[order[idx-1]][order[idx]]
+	}
+	return cost
+}
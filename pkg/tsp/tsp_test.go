@@ -0,0 +1,144 @@
+package tsp
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// distances between points on a line, at the given positions.
+func lineDistances(positions ...int) [][]int {
+	dist := make([][]int, len(positions))
+	for i := range positions {
+		dist[i] = make([]int, len(positions))
+		for j := range positions {
+			d := positions[i] - positions[j]
+			dist[i][j] = max(d, -d)
+		}
+	}
+	return dist
+}
+
+func randomDistances(n int, seed int64) [][]int {
+	r := rand.New(rand.NewSource(seed))
+	dist := make([][]int, n)
+	for i := range dist {
+		dist[i] = make([]int, n)
+		for j := range dist[i] {
+			if i != j {
+				dist[i][j] = 1 + r.Intn(100)
+			}
+		}
+	}
+	return dist
+}
+
+// finds the optimal cost by trying every order.
+func bruteForce(dist [][]int) int {
+	best := Infinity
+	var permute func(order []int, k int)
+	permute = func(order []int, k int) {
+		if k == len(order) {
+			best = min(best, Cost(dist, order))
+			return
+		}
+		for i := k; i < len(order); i++ {
+			order[k], order[i] = order[i], order[k]
+			permute(order, k+1)
+			order[k], order[i] = order[i], order[k]
+		}
+	}
+
+	order := make([]int, len(dist))
+	for i := range order {
+		order[i] = i
+	}
+	permute(order, 1)
+	return best
+}
+
+func TestHeldKarp(t *testing.T) {
+	t.Run("visits points on a line in order", func(t *testing.T) {
+		order, cost := HeldKarp(lineDistances(0, 7, 3, 1, 5))
+
+		if expected := []int{0, 3, 2, 4, 1}; !slices.Equal(order, expected) {
+			t.Errorf("expected order %v but got %v", expected, order)
+		}
+
+		if cost != 7 {
+			t.Errorf("expected cost 7 but got %d", cost)
+		}
+	})
+
+	t.Run("matches brute force on asymmetric distances", func(t *testing.T) {
+		for seed := int64(0); seed < 10; seed++ {
+			dist := randomDistances(7, seed)
+			order, cost := HeldKarp(dist)
+
+			if expected := bruteForce(dist); cost != expected {
+				t.Errorf("seed %d: expected cost %d but got %d", seed, expected, cost)
+			}
+
+			if actual := Cost(dist, order); actual != cost {
+				t.Errorf("seed %d: returned cost %d does not match order cost %d", seed, cost, actual)
+			}
+		}
+	})
+
+	t.Run("reports unreachable nodes", func(t *testing.T) {
+		dist := lineDistances(0, 1, 2)
+		dist[0][2], dist[1][2] = Infinity, Infinity
+
+		if order, cost := HeldKarp(dist); order != nil || cost != Infinity {
+			t.Errorf("expected no order with cost Infinity but got %v with cost %d", order, cost)
+		}
+	})
+
+	t.Run("handles tiny inputs", func(t *testing.T) {
+		if order, cost := HeldKarp([][]int{{0}}); !slices.Equal(order, []int{0}) || cost != 0 {
+			t.Errorf("expected [0] with cost 0 but got %v with cost %d", order, cost)
+		}
+	})
+}
+
+func TestNearestNeighbourAndTwoOpt(t *testing.T) {
+	dist := randomDistances(40, 42)
+
+	nn := NearestNeighbour(dist)
+	improved := TwoOpt(dist, nn)
+
+	for _, order := range [][]int{nn, improved} {
+		sorted := slices.Clone(order)
+		slices.Sort(sorted)
+		for i := range sorted {
+			if sorted[i] != i {
+				t.Fatalf("expected every node to be visited exactly once, got %v", order)
+			}
+		}
+
+		if order[0] != 0 {
+			t.Errorf("expected order to start at node 0, got %v", order)
+		}
+	}
+
+	if Cost(dist, improved) > Cost(dist, nn) {
+		t.Errorf("expected 2-opt to never make the path worse: %d > %d", Cost(dist, improved), Cost(dist, nn))
+	}
+}
+
+func TestSolveApproximatesLargeInputs(t *testing.T) {
+	positions := make([]int, 50)
+	for i := range positions {
+		positions[i] = (i * 37) % 50
+	}
+
+	order, cost := Solve(lineDistances(positions...))
+	if len(order) != len(positions) {
+		t.Fatalf("expected %d nodes in order, got %d", len(positions), len(order))
+	}
+
+	// starting at position 0, the best open path walks the line once.
+	if cost != 49 {
+		t.Errorf("expected cost 49 but got %d", cost)
+	}
+}
@@ -0,0 +1,96 @@
+package pathfind
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrorRouteTooFewWaypoints = errors.New("route: at least two waypoints are required")
+
+// returned when no path could be found for one of the legs of a route.
+// Legs are numbered from 1.
+type UnreachableLegError[T comparable] struct {
+	Leg  int
+	From T
+	To   T
+}
+
+func (e UnreachableLegError[T]) Error() string {
+	return fmt.Sprintf("route: leg %d from %v to %v is unreachable", e.Leg, e.From, e.To)
+}
+
+// a single stretch of a route, between two consecutive waypoints.
+type Leg[T comparable] struct {
+	From T
+	To   T
+	Path []T
+	Cost int
+}
+
+// Like Solver.Walk, paths of a route and its legs run from finish to start.
+type Route[T comparable] struct {
+	Legs []Leg[T]
+	Path []T
+	Cost int
+}
+
+// solves a route passing through the waypoints in the given order, from the
+// first waypoint to the last. Every leg is solved separately using the given
+// algorithm, with the adapter that adapterFor returns for the leg's finish.
+func SolveRoute[T comparable](
+	algorithm Algorithm,
+	waypoints []T,
+	adapterFor func(finish T) Adapter[T],
+) (Route[T], error) {
+	if len(waypoints) < 2 {
+		return Route[T]{}, ErrorRouteTooFewWaypoints
+	}
+
+	legs := make([]Leg[T], 0, len(waypoints)-1)
+	for idx := 1; idx < len(waypoints); idx++ {
+		from, to := waypoints[idx-1], waypoints[idx]
+
+		adapter := adapterFor(to)
+		s := NewSolver[T](algorithm, from, adapter)
+		path := s.Walk()
+		if len(path) == 0 {
+			return Route[T]{}, UnreachableLegError[T]{Leg: idx, From: from, To: to}
+		}
+
+		legs = append(legs, Leg[T]{
+			From: from,
+			To:   to,
+			Path: path,
+			Cost: pathCost(adapter, path),
+		})
+	}
+
+	return newRoute(legs), nil
+}
+
+// joins the legs into a single route. Each leg starts where the previous one
+// finished, so that shared waypoint is only included once.
+func newRoute[T comparable](legs []Leg[T]) Route[T] {
+	route := Route[T]{Legs: legs, Path: []T{}}
+
+	for idx := len(legs) - 1; idx >= 0; idx-- {
+		path := legs[idx].Path
+		if idx < len(legs)-1 {
+			path = path[1:]
+		}
+
+		route.Path = append(route.Path, path...)
+		route.Cost += legs[idx].Cost
+	}
+
+	return route
+}
+
+// returns the cost of walking the given path, which runs from finish to start.
+func pathCost[T comparable](adapter Adapter[T], path []T) int {
+	cost := 0
+	for idx := len(path) - 1; idx > 0; idx-- {
+		cost += edgeCost(adapter, path[idx], path[idx-1])
+	}
+	return cost
+}
@@ -0,0 +1,82 @@
+package pathfind
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// a line of nodes 0 to 5, where moving between n and n+1 costs n+1.
+func lineTowards(finish int) Adapter[int] {
+	return &FuncAdapter[int]{
+		NeighboursFn: func(n int) []int {
+			neighbours := []int{}
+			for _, next := range []int{n - 1, n + 1} {
+				if next >= 0 && next <= 5 {
+					neighbours = append(neighbours, next)
+				}
+			}
+			return neighbours
+		},
+		CostToFinishFn: func(n int) int {
+			if n > finish {
+				return n - finish
+			}
+			return finish - n
+		},
+		IsFinishFn: func(n int) bool { return n == finish },
+		EdgeCostFn: func(from, to int) int { return max(from, to) },
+	}
+}
+
+func TestSolveRoute(t *testing.T) {
+	route, err := SolveRoute(AlgorithmAStar, []int{0, 3, 1}, lineTowards)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expectedLegs := []Leg[int]{
+		{From: 0, To: 3, Path: []int{3, 2, 1, 0}, Cost: 6},
+		{From: 3, To: 1, Path: []int{1, 2, 3}, Cost: 5},
+	}
+
+	if len(route.Legs) != len(expectedLegs) {
+		t.Fatalf("expected %d legs but got %d", len(expectedLegs), len(route.Legs))
+	}
+
+	for idx, expected := range expectedLegs {
+		leg := route.Legs[idx]
+		if leg.From != expected.From || leg.To != expected.To || leg.Cost != expected.Cost ||
+			!slices.Equal(leg.Path, expected.Path) {
+			t.Errorf("expected leg %d to be %+v but got %+v", idx+1, expected, leg)
+		}
+	}
+
+	// from the last waypoint back to the first, passing 3 only once.
+	if expected := []int{1, 2, 3, 2, 1, 0}; !slices.Equal(route.Path, expected) {
+		t.Errorf("expected path %v but got %v", expected, route.Path)
+	}
+
+	if route.Cost != 11 {
+		t.Errorf("expected cost 11 but got %d", route.Cost)
+	}
+}
+
+func TestSolveRouteUnreachableLeg(t *testing.T) {
+	_, err := SolveRoute(AlgorithmAStar, []int{0, 2, 7, 1}, lineTowards)
+
+	var legErr UnreachableLegError[int]
+	if !errors.As(err, &legErr) {
+		t.Fatalf("expected an UnreachableLegError, got %v", err)
+	}
+
+	if legErr.Leg != 2 || legErr.From != 2 || legErr.To != 7 {
+		t.Errorf("expected leg 2 from 2 to 7 to be unreachable, got %+v", legErr)
+	}
+}
+
+func TestSolveRouteTooFewWaypoints(t *testing.T) {
+	if _, err := SolveRoute(AlgorithmAStar, []int{0}, lineTowards); !errors.Is(err, ErrorRouteTooFewWaypoints) {
+		t.Errorf("expected ErrorRouteTooFewWaypoints, got %v", err)
+	}
+}
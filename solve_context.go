@@ -2,6 +2,12 @@ package pathfind
 
 type SolveContext[T comparable] struct {
 	MaxCost int
+	Workers int
+
+	CheckConsistency bool
+	ReopenClosed     bool
+	Frontier         FrontierKind
+	AllowPartial     bool
 
 	Publish   func(Event)
 	Adapter   func() Adapter[T]
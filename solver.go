@@ -5,31 +5,58 @@ type Algorithm int
 const (
 	AlgorithmBFS Algorithm = iota
 	AlgorithmAStar
+	AlgorithmParallelAStar
 )
 
 type Solver[T comparable] struct {
 	adapter  Adapter[T]
 	eventlog []Event
-	visited  map[T]struct{}
 
 	// delegate algorithm
 	walker Walker[T]
 
 	// some runtime options
 	MaxCost int
+
+	// number of goroutines used by AlgorithmParallelAStar,
+	// defaults to GOMAXPROCS. Only spreads the work when the adapter
+	// implements Hasher or Indexer.
+	Workers int
+
+	// makes AlgorithmAStar publish EventHeuristicInconsistent whenever it
+	// comes across an edge along which CostToFinish drops too much. Meant
+	// for debugging adapters, as it costs an extra estimate per edge.
+	CheckConsistency bool
+
+	// makes AlgorithmAStar move expanded nodes back onto the frontier when
+	// a cheaper route to them turns up. Only needed to stay optimal with
+	// heuristics that are admissible but not consistent.
+	ReopenClosed bool
+
+	// the data structure holding candidates that are yet to be expanded.
+	// Leaving it at FrontierDefault lets the algorithm choose. AlgorithmAStar
+	// falls back to a binary heap for kinds it can't stay optimal with.
+	Frontier FrontierKind
+
+	// makes Walk return the path to the expanded node with the lowest
+	// CostToFinish when the finish can't be reached, or isn't reached
+	// within MaxCost. Status tells such partial paths apart.
+	AllowPartial bool
+
+	// the nodes expanded so far. Defaults to a bitset when the adapter
+	// implements Indexer, and to a map otherwise.
+	Visited VisitedSet[T]
 }
 
 func (s *Solver[T]) isVisited(c T) bool {
-	_, found := s.visited[c]
-	return found
+	return s.Visited.Contains(c)
 }
 
 func (s *Solver[T]) visit(c T) {
-	s.visited[c] = struct{}{}
+	s.Visited.Add(c)
 }
 
 func (s *Solver[T]) publish(e Event) {
-	// Note: not threadsafe, yet?
 	s.eventlog = append(s.eventlog, e)
 }
 
@@ -43,7 +70,13 @@ func (s *Solver[T]) EventLog() []Event {
 
 func (s *Solver[T]) Walk() []T {
 	return s.walker.Walk(SolveContext[T]{
-		MaxCost:   s.MaxCost,
+		MaxCost:          s.MaxCost,
+		Workers:          s.Workers,
+		CheckConsistency: s.CheckConsistency,
+		ReopenClosed:     s.ReopenClosed,
+		Frontier:         s.Frontier,
+		AllowPartial:     s.AllowPartial,
+
 		Publish:   s.publish,
 		Adapter:   s.getAdapter,
 		IsVisited: s.isVisited,
@@ -59,6 +92,9 @@ func makeWalker[T comparable](algorithm Algorithm, start T) Walker[T] {
 	case AlgorithmAStar:
 		return newAStar[T](start)
 
+	case AlgorithmParallelAStar:
+		return newParallelAStar[T](start)
+
 	default:
 		return newAStar[T](start)
 	}
@@ -69,6 +105,6 @@ func NewSolver[T comparable](algorithm Algorithm, start T, adapter Adapter[T]) S
 		adapter:  adapter,
 		walker:   makeWalker[T](algorithm, start),
 		eventlog: []Event{},
-		visited:  make(map[T]struct{}),
+		Visited:  visitedSetFor(adapter),
 	}
 }
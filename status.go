@@ -0,0 +1,63 @@
+package pathfind
+
+// tells how a walk ended. Anything but StatusFinishReached means the path
+// returned by Walk is either empty or, with AllowPartial, partial.
+type Status int
+
+const (
+	// Walk hasn't been called, or hasn't returned yet.
+	StatusPending Status = iota
+	StatusFinishReached
+
+	// the search stopped at MaxCost before reaching the finish.
+	StatusMaxCostReached
+
+	// the finish can't be reached from the start.
+	StatusUnsolvable
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusFinishReached:
+		return "finish reached"
+	case StatusMaxCostReached:
+		return "max cost reached"
+	case StatusUnsolvable:
+		return "unsolvable"
+	default:
+		return "pending"
+	}
+}
+
+// returns the actions taken along the path returned by the last walk, in
+// the same order as the path, so the first action leads into the finish.
+// Returns nil when the adapter doesn't label its transitions.
+func (s *Solver[T]) Actions() []string {
+	for _, e := range s.EventLog() {
+		switch e := e.(type) {
+		case EventFinishReached[T]:
+			return e.Actions
+		case EventPartialPath[T]:
+			return e.Actions
+		}
+	}
+	return nil
+}
+
+// returns how the last walk ended, as told by its events.
+func (s *Solver[T]) Status() Status {
+	status := StatusPending
+	for _, e := range s.EventLog() {
+		switch e.(type) {
+		case EventFinishReached[T]:
+			return StatusFinishReached
+		case EventMaxCostReached:
+			status = StatusMaxCostReached
+		case EventUnsolvable:
+			if status == StatusPending {
+				status = StatusUnsolvable
+			}
+		}
+	}
+	return status
+}
@@ -0,0 +1,73 @@
+package pathfind
+
+import (
+	"errors"
+
+	"github.com/tmw/pathfind/pkg/tsp"
+)
+
+var ErrorTourUnreachable = errors.New("tour: not all targets can be reached")
+
+// solves the shortest route from start that visits every target once, in
+// whichever order is cheapest. The route ends at the last target visited and
+// does not return to start.
+//
+// The distance between every pair of stops is solved using the given
+// algorithm, with the adapter that adapterFor returns for the pair's finish.
+// Up to tsp.HeldKarpLimit stops are ordered exactly, larger tours are
+// approximated.
+func SolveTour[T comparable](
+	algorithm Algorithm,
+	start T,
+	targets []T,
+	adapterFor func(finish T) Adapter[T],
+) (Route[T], error) {
+	if len(targets) == 0 {
+		return Route[T]{}, ErrorRouteTooFewWaypoints
+	}
+
+	stops := append([]T{start}, targets...)
+	dist := make([][]int, len(stops))
+	paths := make([][][]T, len(stops))
+
+	for i, from := range stops {
+		dist[i] = make([]int, len(stops))
+		paths[i] = make([][]T, len(stops))
+
+		// tours never return to start, so there's no need to solve paths to it.
+		for j := 1; j < len(stops); j++ {
+			if i == j {
+				continue
+			}
+
+			adapter := adapterFor(stops[j])
+			s := NewSolver[T](algorithm, from, adapter)
+			path := s.Walk()
+			if len(path) == 0 {
+				dist[i][j] = tsp.Infinity
+				continue
+			}
+
+			dist[i][j] = pathCost(adapter, path)
+			paths[i][j] = path
+		}
+	}
+
+	order, cost := tsp.Solve(dist)
+	if cost >= tsp.Infinity {
+		return Route[T]{}, ErrorTourUnreachable
+	}
+
+	legs := make([]Leg[T], 0, len(targets))
+	for idx := 1; idx < len(order); idx++ {
+		from, to := order[idx-1], order[idx]
+		legs = append(legs, Leg[T]{
+			From: stops[from],
+			To:   stops[to],
+			Path: paths[from][to],
+			Cost: dist[from][to],
+		})
+	}
+
+	return newRoute(legs), nil
+}
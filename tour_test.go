@@ -0,0 +1,54 @@
+package pathfind
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tmw/pathfind/pkg/arena"
+)
+
+func adapterTowards(a *arena.Arena) func(arena.Coordinate) Adapter[arena.Coordinate] {
+	return func(finish arena.Coordinate) Adapter[arena.Coordinate] {
+		return arena.NewAdapter(a, finish)
+	}
+}
+
+func TestSolveTour(t *testing.T) {
+	a, err := arena.Parse("S....\n.....\n....F")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targets := []arena.Coordinate{
+		arena.NewCoordinate(4, 2),
+		arena.NewCoordinate(4, 0),
+		arena.NewCoordinate(0, 2),
+	}
+	route, err := SolveTour(AlgorithmAStar, a.StartCoordinate(), targets, adapterTowards(a))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// down to the bottom left, right along the bottom and up to the top right.
+	if route.Cost != 8 {
+		t.Errorf("expected cost 8 but got %d", route.Cost)
+	}
+
+	if len(route.Legs) != len(targets) {
+		t.Errorf("expected %d legs but got %d", len(targets), len(route.Legs))
+	}
+}
+
+func TestSolveTourUnreachable(t *testing.T) {
+	a, err := arena.Parse("S..#.\n...#.\n..F#.")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targets := []arena.Coordinate{a.FinishCoordinate(), arena.NewCoordinate(4, 0)}
+	_, err = SolveTour(AlgorithmAStar, a.StartCoordinate(), targets, adapterTowards(a))
+
+	if !errors.Is(err, ErrorTourUnreachable) {
+		t.Errorf("expected ErrorTourUnreachable, got %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package pathfind
+
+// a move from one state to the next, labelled with the action taking it.
+type Transition[T comparable] struct {
+	Action string
+	State  T
+	Cost   int
+}
+
+// adapters can optionally implement ActionAdapter when the actions leading
+// from one state to the next matter, and not just the states. Neighbours
+// should list the states of the transitions. When several transitions lead
+// to the same state, the cheapest one is taken.
+type ActionAdapter[T comparable] interface {
+	Adapter[T]
+
+	// to return the transitions leading away from the given T
+	Transitions(T) []Transition[T]
+}
+
+// returns the adapter as an ActionAdapter, unless it doesn't label its
+// transitions. A FuncAdapter only does so when TransitionsFn is set, and an
+// Unwrapper only when the adapter it wraps does.
+func labelsActions[T comparable](adapter Adapter[T]) (ActionAdapter[T], bool) {
+	switch a := adapter.(type) {
+	case *FuncAdapter[T]:
+		if a.TransitionsFn == nil {
+			return nil, false
+		}
+
+	case Unwrapper[T]:
+		if _, ok := labelsActions(a.Unwrap()); !ok {
+			return nil, false
+		}
+	}
+
+	actionAdapter, ok := adapter.(ActionAdapter[T])
+	return actionAdapter, ok
+}
+
+// returns the cheapest transition from -> to, if there is any.
+func cheapestTransition[T comparable](adapter ActionAdapter[T], from, to T) (Transition[T], bool) {
+	var (
+		cheapest Transition[T]
+		found    bool
+	)
+
+	for _, t := range adapter.Transitions(from) {
+		if t.State == to && (!found || t.Cost < cheapest.Cost) {
+			cheapest, found = t, true
+		}
+	}
+
+	return cheapest, found
+}
+
+// returns the actions taken along a path running from finish to start, in
+// the same order: actions[i] leads from path[i+1] to path[i]. Returns nil
+// when the adapter doesn't label its transitions.
+func actionsAlong[T comparable](adapter Adapter[T], path []T) []string {
+	actionAdapter, ok := labelsActions(adapter)
+	if !ok || len(path) == 0 {
+		return nil
+	}
+
+	actions := make([]string, len(path)-1)
+	for idx := range actions {
+		t, _ := cheapestTransition(actionAdapter, path[idx+1], path[idx])
+		actions[idx] = t.Action
+	}
+
+	return actions
+}
@@ -0,0 +1,92 @@
+package pathfind
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// the litres of water in a 3 and a 5 litre jug.
+type jugs [2]int
+
+var capacities = jugs{3, 5}
+
+// fill, empty or pour one jug into the other, until the big one holds 4.
+func jugPuzzle() *FuncAdapter[jugs] {
+	return &FuncAdapter[jugs]{
+		TransitionsFn: func(j jugs) []Transition[jugs] {
+			transitions := []Transition[jugs]{}
+			for a := 0; a < 2; a++ {
+				b := 1 - a
+
+				filled := j
+				filled[a] = capacities[a]
+				transitions = append(transitions, Transition[jugs]{Action: fmt.Sprintf("fill %d", capacities[a]), State: filled, Cost: 1})
+
+				emptied := j
+				emptied[a] = 0
+				transitions = append(transitions, Transition[jugs]{Action: fmt.Sprintf("empty %d", capacities[a]), State: emptied, Cost: 1})
+
+				poured := j
+				amount := min(j[a], capacities[b]-j[b])
+				poured[a] -= amount
+				poured[b] += amount
+				transitions = append(transitions, Transition[jugs]{Action: fmt.Sprintf("pour %d into %d", capacities[a], capacities[b]), State: poured, Cost: 1})
+			}
+			return transitions
+		},
+		CostToFinishFn: func(jugs) int { return 0 },
+		IsFinishFn:     func(j jugs) bool { return j[1] == 4 },
+	}
+}
+
+func TestActions(t *testing.T) {
+	// in the order they are taken, the path is unique as starting with the
+	// 3 litre jug takes two more steps.
+	expected := []string{"fill 5", "pour 5 into 3", "empty 3", "pour 5 into 3", "fill 5", "pour 5 into 3"}
+	slices.Reverse(expected)
+
+	for _, algorithm := range []Algorithm{AlgorithmBFS, AlgorithmAStar, AlgorithmParallelAStar} {
+		s := NewSolver[jugs](algorithm, jugs{}, jugPuzzle())
+		path := s.Walk()
+		actions := s.Actions()
+
+		if len(path) != 7 {
+			t.Fatalf("algorithm %d: expected a path of 7 states, got %v", algorithm, path)
+		}
+
+		if len(actions) != len(path)-1 {
+			t.Fatalf("algorithm %d: expected %d actions, got %v", algorithm, len(path)-1, actions)
+		}
+
+		if !slices.Equal(actions, expected) {
+			t.Errorf("algorithm %d: expected actions %v, got %v", algorithm, expected, actions)
+		}
+	}
+}
+
+func TestActionsWithoutActionAdapter(t *testing.T) {
+	s := NewSolver[string](AlgorithmAStar, "s", inconsistentGraph())
+	s.Walk()
+
+	if s.Actions() != nil {
+		t.Errorf("expected no actions for an adapter without transitions, got %v", s.Actions())
+	}
+}
+
+func TestFuncAdapterTransitions(t *testing.T) {
+	adapter := jugPuzzle()
+
+	if len(adapter.Neighbours(jugs{})) != 6 {
+		t.Errorf("expected 6 neighbours, got %v", adapter.Neighbours(jugs{}))
+	}
+
+	if cost := adapter.EdgeCost(jugs{}, jugs{3, 0}); cost != 1 {
+		t.Errorf("expected edge cost 1, got %d", cost)
+	}
+
+	transitions := inconsistentGraph().Transitions("s")
+	if len(transitions) != 2 || transitions[0] != (Transition[string]{State: "a", Cost: 4}) {
+		t.Errorf("expected unlabelled transitions made up from neighbours, got %v", transitions)
+	}
+}
@@ -0,0 +1,79 @@
+package pathfind
+
+import (
+	"github.com/tmw/pathfind/pkg/bitset"
+)
+
+// keeps track of the nodes a walker has expanded.
+type VisitedSet[T comparable] interface {
+	Contains(T) bool
+	Add(T)
+}
+
+// adapters can optionally implement Indexer when their nodes can be numbered
+// densely, such as the cells of a grid. Solvers then keep track of visited
+// nodes in a bitset instead of a map, and AlgorithmAStar keeps the nodes it
+// has reached in pages of a slice.
+type Indexer[T comparable] interface {
+	// to return a unique, non-negative number for the given T. Numbers
+	// should stay small, as the bitset grows to fit the largest.
+	Index(T) int
+}
+
+type mapVisitedSet[T comparable] map[T]struct{}
+
+// returns a VisitedSet backed by a map, which works for any T.
+func NewMapVisitedSet[T comparable]() VisitedSet[T] {
+	return mapVisitedSet[T]{}
+}
+
+func (s mapVisitedSet[T]) Contains(n T) bool {
+	_, found := s[n]
+	return found
+}
+
+func (s mapVisitedSet[T]) Add(n T) {
+	s[n] = struct{}{}
+}
+
+type bitsetVisitedSet[T comparable] struct {
+	indexer Indexer[T]
+	bits    *bitset.Bitset
+}
+
+// returns a VisitedSet storing a single bit per node, as numbered by the
+// indexer. Size is a hint for the number of nodes, the set grows as needed.
+func NewBitsetVisitedSet[T comparable](indexer Indexer[T], size int) VisitedSet[T] {
+	return &bitsetVisitedSet[T]{
+		indexer: indexer,
+		bits:    bitset.New(size),
+	}
+}
+
+func (s *bitsetVisitedSet[T]) Contains(n T) bool {
+	return s.bits.Has(s.indexer.Index(n))
+}
+
+func (s *bitsetVisitedSet[T]) Add(n T) {
+	s.bits.Set(s.indexer.Index(n))
+}
+
+// returns a bitset backed VisitedSet when the adapter implements Indexer,
+// and a map backed one otherwise.
+func visitedSetFor[T comparable](adapter Adapter[T]) VisitedSet[T] {
+	if indexer, ok := optional[Indexer[T]](adapter); ok {
+		return NewBitsetVisitedSet[T](indexer, 0)
+	}
+
+	return NewMapVisitedSet[T]()
+}
+
+// returns the Index method of the adapter when it implements Indexer, and
+// nil otherwise, for use with keymap.New.
+func indexOf[T comparable](adapter Adapter[T]) func(T) int {
+	if indexer, ok := optional[Indexer[T]](adapter); ok {
+		return indexer.Index
+	}
+
+	return nil
+}
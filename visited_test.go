@@ -0,0 +1,128 @@
+package pathfind
+
+import (
+	"testing"
+
+	"github.com/tmw/pathfind/pkg/arena"
+)
+
+// numbers the cells of a grid that is 100 cells wide.
+type gridIndexer struct{}
+
+func (gridIndexer) Index(n [2]int) int {
+	return n[1]*100 + n[0]
+}
+
+func TestVisitedSets(t *testing.T) {
+	sets := map[string]VisitedSet[[2]int]{
+		"map":    NewMapVisitedSet[[2]int](),
+		"bitset": NewBitsetVisitedSet[[2]int](gridIndexer{}, 0),
+	}
+
+	for name, set := range sets {
+		set := set
+		t.Run(name, func(t *testing.T) {
+			set.Add([2]int{3, 4})
+			set.Add([2]int{99, 99})
+
+			for _, n := range [][2]int{{3, 4}, {99, 99}} {
+				if !set.Contains(n) {
+					t.Errorf("expected %v to be visited", n)
+				}
+			}
+
+			for _, n := range [][2]int{{4, 3}, {0, 0}, {98, 99}} {
+				if set.Contains(n) {
+					t.Errorf("expected %v not to be visited", n)
+				}
+			}
+		})
+	}
+}
+
+func TestSolverPicksVisitedSet(t *testing.T) {
+	a, err := arena.Parse("S..\n.#.\n..F")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewSolver[arena.Coordinate](AlgorithmAStar, a.StartCoordinate(), arena.NewAdapter(a, a.FinishCoordinate()))
+	if _, ok := s.Visited.(*bitsetVisitedSet[arena.Coordinate]); !ok {
+		t.Errorf("expected a bitset for an adapter implementing Indexer, got %T", s.Visited)
+	}
+
+	if len(s.Walk()) != 5 {
+		t.Error("expected a path of 5 cells")
+	}
+
+	f := NewSolver[string](AlgorithmAStar, "s", inconsistentGraph())
+	if _, ok := f.Visited.(mapVisitedSet[string]); !ok {
+		t.Errorf("expected a map for an adapter not implementing Indexer, got %T", f.Visited)
+	}
+}
+
+// marks every cell of a 1000x1000 arena as visited.
+func BenchmarkVisitedSets(b *testing.B) {
+	a := benchmarkArena(b, 1000)
+	adapter := arena.NewAdapter(a, a.FinishCoordinate())
+
+	cells := []arena.Coordinate{}
+	for y := 0; y < a.Height(); y++ {
+		for x := 0; x < a.Width(); x++ {
+			cells = append(cells, arena.NewCoordinate(x, y))
+		}
+	}
+
+	sets := map[string]func() VisitedSet[arena.Coordinate]{
+		"map":    NewMapVisitedSet[arena.Coordinate],
+		"bitset": func() VisitedSet[arena.Coordinate] { return NewBitsetVisitedSet[arena.Coordinate](adapter, 0) },
+	}
+
+	for name, newSet := range sets {
+		newSet := newSet
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				set := newSet()
+				for _, c := range cells {
+					set.Add(c)
+				}
+			}
+		})
+	}
+}
+
+// hides the Indexer of the adapter it wraps.
+type unindexed[T comparable] struct {
+	Adapter[T]
+}
+
+// solves a 300x300 arena with and without the adapter implementing Indexer,
+// which decides between maps and slices for the bookkeeping of a walk.
+func BenchmarkWalkIndexed(b *testing.B) {
+	a := benchmarkArena(b, 300)
+	algorithms := map[string]Algorithm{"astar": AlgorithmAStar, "bfs": AlgorithmBFS}
+	adapters := map[string]func() Adapter[arena.Coordinate]{
+		"map": func() Adapter[arena.Coordinate] {
+			return unindexed[arena.Coordinate]{arena.NewAdapter(a, a.FinishCoordinate())}
+		},
+		"indexed": func() Adapter[arena.Coordinate] {
+			return arena.NewAdapter(a, a.FinishCoordinate())
+		},
+	}
+
+	for algorithmName, algorithm := range algorithms {
+		for adapterName, newAdapter := range adapters {
+			algorithm, newAdapter := algorithm, newAdapter
+			b.Run(algorithmName+"/"+adapterName, func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					s := NewSolver[arena.Coordinate](algorithm, a.StartCoordinate(), newAdapter())
+					if len(s.Walk()) == 0 {
+						b.Fatal("expected a path")
+					}
+				}
+			})
+		}
+	}
+}